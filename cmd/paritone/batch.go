@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+type batchRequest struct {
+	World       *api.WorldSpec    `json:"world"`
+	Queries     []json.RawMessage `json:"queries"`
+	Parallelism int               `json:"parallelism,omitempty"`
+}
+
+func findPathsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	var req batchRequest
+	if err := decodeJSON(r, r.Body, &req); err != nil {
+		writeError(w, invalidRequest(err))
+		return
+	}
+
+	if req.World == nil {
+		writeError(w, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest, "a batch needs a world shared by all queries"))
+		return
+	}
+	if len(req.Queries) == 0 {
+		writeError(w, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest, "a batch needs at least one query"))
+		return
+	}
+	if len(req.Queries) > cfg.MaxBatchQueries {
+		writeError(w, newAPIError(http.StatusRequestEntityTooLarge, api.CodeRequestTooLarge,
+			"%d queries exceeds the limit of %d per batch", len(req.Queries), cfg.MaxBatchQueries).
+			With("limit", "maxBatchQueries").With("limitValue", cfg.MaxBatchQueries))
+		return
+	}
+	if req.Parallelism < 0 {
+		writeError(w, newAPIError(http.StatusBadRequest, api.CodeInvalidOptions, "parallelism must not be negative").
+			With("field", "parallelism").With("value", req.Parallelism))
+		return
+	}
+
+	queries := make([]api.PathRequest, len(req.Queries))
+	ids := make([]string, len(req.Queries))
+	for i, raw := range req.Queries {
+		query := api.BatchQuery{PathRequest: newPathRequest()}
+		if err := decodeJSON(r, bytes.NewReader(raw), &query); err != nil {
+			writeError(w, invalidRequest(fmt.Errorf("query %d: %w", i, err)).With("index", i))
+			return
+		}
+		queries[i], ids[i] = query.PathRequest, query.ID
+	}
+
+	parallelism := batchParallelism(req.Parallelism, len(queries))
+
+	loggerFrom(r.Context()).Debug("batch request", "queries", len(queries), "parallelism", parallelism,
+		"world", describeWorldSpec(req.World))
+
+	gameWorld, release, err := batchWorld(*req.World, queries)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer release()
+
+	ctx := r.Context()
+	if cfg.SearchTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.SearchTimeout.Duration)
+		defer cancel()
+	}
+
+	started := time.Now()
+	results, durations := runBatch(ctx, gameWorld, queries, ids, parallelism)
+	summary := summariseBatch(results, durations, parallelism, time.Since(started))
+
+	annotate(w,
+		slog.Int("queries", summary.Queries),
+		slog.Int("found", summary.Found),
+		slog.String("outcome", "completed"))
+
+	writeJSON(w, http.StatusOK, api.BatchResponse{Results: results, Summary: summary})
+}
+
+func batchParallelism(requested, queries int) int {
+	limit := max(1, cap(searchSlots)/2)
+	if requested > 0 && requested < limit {
+		limit = requested
+	}
+	if queries < limit {
+		limit = queries
+	}
+	return limit
+}
+
+func batchWorld(spec api.WorldSpec, queries []api.PathRequest) (*world.World, func(), error) {
+	if spec.ID != "" {
+		if countWorldSources(spec) > 0 {
+			return nil, nil, fmt.Errorf("world id cannot be combined with seed, scenario or blocks/regions")
+		}
+
+		sess, err := worldStore.Get(spec.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		gameWorld, release := sess.Acquire()
+		return gameWorld, release, nil
+	}
+
+	include := make([]pathfinding.Point, 0, 2*len(queries))
+	for _, q := range queries {
+		include = append(include,
+			pathfinding.Point{X: q.StartX, Y: q.StartY, Z: q.StartZ},
+			pathfinding.Point{X: q.EndX, Y: q.EndY, Z: q.EndZ})
+	}
+
+	gameWorld, err := worldFromSpec(spec, include...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gameWorld, func() {}, nil
+}
+
+func runBatch(ctx context.Context, gameWorld *world.World, queries []api.PathRequest, ids []string, parallelism int) ([]api.BatchResult, []time.Duration) {
+	results := make([]api.BatchResult, len(queries))
+	durations := make([]time.Duration, len(queries))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], durations[i] = runBatchQuery(ctx, gameWorld, queries[i])
+				results[i].Index = i
+				results[i].ID = ids[i]
+			}
+		}()
+	}
+
+	for i := range queries {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results, durations
+}
+
+func runBatchQuery(ctx context.Context, gameWorld *world.World, q api.PathRequest) (api.BatchResult, time.Duration) {
+	result := api.BatchResult{Algorithm: q.Algorithm}
+	var elapsed time.Duration
+
+	fail := func(err error) (api.BatchResult, time.Duration) {
+		result.Error = toAPIError(err)
+		return result, elapsed
+	}
+
+	if err := validatePathRequest(q); err != nil {
+		return fail(err)
+	}
+	if q.World != nil {
+		return fail(newAPIError(http.StatusBadRequest, api.CodeInvalidRequest,
+			"queries share the batch world and cannot carry their own"))
+	}
+
+	start := pathfinding.Point{X: q.StartX, Y: q.StartY, Z: q.StartZ}
+	goal := pathfinding.Point{X: q.EndX, Y: q.EndY, Z: q.EndZ}
+	if !gameWorld.InBounds(start) {
+		return fail(outOfBoundsError(api.CodeStartOutOfBounds, "start", start, gameWorld))
+	}
+	if !gameWorld.InBounds(goal) {
+		return fail(outOfBoundsError(api.CodeGoalOutOfBounds, "goal", goal, gameWorld))
+	}
+
+	algorithm := algorithmFor(q.Algorithm)
+	options := pathOptions(q)
+	options.Context = ctx
+	options.Logger = loggerFrom(ctx)
+
+	if err := pathfinding.CheckEndpoints(start, goal, gameWorld, options); err != nil {
+		return fail(searchError(algorithm, err))
+	}
+
+	if err := acquireSearchSlot(ctx, true); err != nil {
+		return fail(err)
+	}
+	defer releaseSearchSlot()
+
+	found := runAlgorithm(algorithm, start, goal, gameWorld, options)
+	elapsed = found.ComputationTime
+
+	if found.Err != nil {
+		return fail(searchError(algorithm, found.Err))
+	}
+
+	response := newPathResponse(found, gameWorld)
+	result.Found = true
+	result.Result = &response
+	return result, elapsed
+}
+
+func summariseBatch(results []api.BatchResult, durations []time.Duration, parallelism int, wall time.Duration) api.BatchSummary {
+	summary := api.BatchSummary{
+		Queries:        len(results),
+		Parallelism:    parallelism,
+		WallTimeMicros: wall.Microseconds(),
+		ByAlgorithm:    make(map[string]api.BatchAlgorithmSummary),
+	}
+
+	type totals struct {
+		queries, found, nodes int
+		time                  time.Duration
+	}
+	perAlgorithm := make(map[string]*totals)
+
+	var pathLength int
+	var cost float64
+
+	for i, result := range results {
+		t, ok := perAlgorithm[result.Algorithm]
+		if !ok {
+			t = &totals{}
+			perAlgorithm[result.Algorithm] = t
+		}
+		t.queries++
+		t.time += durations[i]
+		summary.TotalComputationTimeMicros += durations[i].Microseconds()
+
+		if result.Error != nil {
+			summary.Failed++
+			if summary.Errors == nil {
+				summary.Errors = make(map[string]int)
+			}
+			summary.Errors[result.Error.Code]++
+			if explored, ok := result.Error.Details["nodesExplored"].(int); ok {
+				t.nodes += explored
+				summary.TotalNodesExplored += explored
+			}
+			continue
+		}
+
+		summary.Found++
+		t.found++
+		t.nodes += result.Result.NodesExplored
+		summary.TotalNodesExplored += result.Result.NodesExplored
+		pathLength += result.Result.BlocksTraversed
+		cost += result.Result.TotalCost
+	}
+
+	if summary.Found > 0 {
+		summary.MeanPathLength = float64(pathLength) / float64(summary.Found)
+		summary.MeanTotalCost = cost / float64(summary.Found)
+	}
+
+	for name, t := range perAlgorithm {
+		summary.ByAlgorithm[name] = api.BatchAlgorithmSummary{
+			Queries:                   t.queries,
+			Found:                     t.found,
+			MeanNodesExplored:         float64(t.nodes) / float64(t.queries),
+			MeanComputationTimeMicros: float64(t.time.Microseconds()) / float64(t.queries),
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/bench"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
+)
+
+var benchFormats = map[string]func(io.Writer, bench.Report) error{
+	"json": bench.WriteJSON,
+	"csv":  bench.WriteCSV,
+}
+
+func benchCommand(args []string) int {
+	fs := flag.NewFlagSet("paritone bench", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: paritone bench [flags]\n\n")
+		fmt.Fprintf(fs.Output(), "Runs every algorithm over the scenarios and random start/goal pairs and writes the measurements as JSON or CSV.\n\n")
+		fs.PrintDefaults()
+	}
+
+	var (
+		scenarioList  = fs.String("scenarios", "all", "comma-separated scenario IDs, all, or empty for none: "+strings.Join(scenarios.IDs(), ", "))
+		algorithmList = fs.String("algorithms", "all", "comma-separated algorithms or all: "+strings.Join(pathfinding.AlgorithmNames(), ", "))
+		random        = fs.Int("random", 10, "number of random start/goal pairs on a generated world")
+		seed          = fs.Int64("seed", 1, "seed for the generated world and the random pairs")
+		warmup        = fs.Int("warmup", 1, "unmeasured runs before each measurement")
+		repetitions   = fs.Int("repetitions", 10, "measured runs per algorithm and case")
+		timeout       = fs.Duration("timeout", 10*time.Second, "time limit for each search, 0 for none")
+		allowBreaking = fs.Bool("allow-breaking", false, "allow breaking blocks")
+		allowPlacing  = fs.Bool("allow-placing", false, "allow placing blocks")
+		avoidWater    = fs.Bool("avoid-water", false, "avoid water")
+		minimise      = fs.Bool("minimise-height", false, "minimise vertical movement")
+		maxIterations = fs.Int("max-iterations", cfg.Defaults.MaxIterations, "iteration limit for iterative algorithms such as IDA*")
+		maxNodes      = fs.Int("max-nodes", cfg.MaxSearchNodes, "stop a search after expanding this many nodes")
+		maxFrontier   = fs.Int("max-frontier", cfg.MaxSearchFrontier, "stop a search when its open set grows past this size")
+		formatName    = fs.String("format", "", "output format, json or csv (default: from the -o extension, else json)")
+		output        = fs.String("o", "", "write to this file instead of standard output")
+		quiet         = fs.Bool("q", false, "do not print a line per measurement to standard error")
+		baseline      = fs.String("baseline", "", "compare the results to this saved JSON report and exit 1 on regressions or missing results")
+		current       = fs.String("current", "", "compare this saved JSON report to -baseline instead of running the benchmarks")
+		comparison    = fs.String("comparison", "", "write the comparison with -baseline to this file as JSON")
+		thresholds    = bench.DefaultThresholds()
+	)
+	fs.Float64Var(&thresholds.Time, "time-threshold", thresholds.Time, "relative p50 time increase that counts as a regression")
+	fs.Float64Var(&thresholds.Nodes, "nodes-threshold", thresholds.Nodes, "relative increase in nodes explored that counts as a regression")
+	fs.Float64Var(&thresholds.Cost, "cost-threshold", thresholds.Cost, "relative increase in path cost that counts as a regression")
+	fs.Float64Var(&thresholds.Alpha, "alpha", thresholds.Alpha, "significance level for time changes")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *current != "" {
+		if *baseline == "" {
+			fmt.Fprintln(os.Stderr, "paritone bench: -current needs -baseline")
+			return 2
+		}
+		report, err := readBenchReport(*current)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+			return 2
+		}
+		return compareBench(*baseline, report, thresholds, *comparison)
+	}
+
+	if *formatName == "" {
+		*formatName = "json"
+		if strings.EqualFold(filepath.Ext(*output), ".csv") {
+			*formatName = "csv"
+		}
+	}
+	write, ok := benchFormats[*formatName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "paritone bench: unknown format %q, expected json or csv\n", *formatName)
+		return 2
+	}
+
+	options := pathfinding.PathfindingOptions{
+		AllowBreaking:  *allowBreaking,
+		AllowPlacing:   *allowPlacing,
+		AvoidWater:     *avoidWater,
+		MinimiseHeight: *minimise,
+		MaxIterations:  *maxIterations,
+		MaxNodes:       *maxNodes,
+		MaxFrontier:    *maxFrontier,
+	}
+	algorithms, err := benchAlgorithms(*algorithmList, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+		return 2
+	}
+
+	cases, err := benchCases(*scenarioList, *seed, *random)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+		return 2
+	}
+	if len(cases) == 0 {
+		fmt.Fprintln(os.Stderr, "paritone bench: no scenarios or random pairs to run")
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	config := bench.Config{
+		Algorithms:  algorithms,
+		Options:     options,
+		Warmup:      *warmup,
+		Repetitions: *repetitions,
+		Timeout:     *timeout,
+	}
+	if !*quiet {
+		config.OnResult = printBenchResult
+	}
+
+	report, err := bench.Run(ctx, cases, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+		if len(report.Results) == 0 {
+			return 1
+		}
+	}
+
+	if writeErr := writeOutput(*output, func(w io.Writer) error { return write(w, report) }); writeErr != nil {
+		fmt.Fprintf(os.Stderr, "paritone bench: %v\n", writeErr)
+		return 1
+	}
+	if err != nil {
+		return 1
+	}
+
+	if *baseline != "" {
+		return compareBench(*baseline, report, thresholds, *comparison)
+	}
+	return 0
+}
+
+func compareBench(baselineFile string, report bench.Report, thresholds bench.Thresholds, output string) int {
+	baseline, err := readBenchReport(baselineFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+		return 2
+	}
+
+	comparison := bench.Compare(baseline, report, thresholds)
+	bench.WriteComparison(os.Stderr, comparison)
+
+	if output != "" {
+		err := writeOutput(output, func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(comparison)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone bench: %v\n", err)
+			return 1
+		}
+	}
+
+	if comparison.Failed() {
+		return 1
+	}
+	return 0
+}
+
+func readBenchReport(name string) (bench.Report, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return bench.Report{}, err
+	}
+	defer file.Close()
+
+	report, err := bench.ReadJSON(file)
+	if err != nil {
+		return bench.Report{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return report, nil
+}
+
+func benchAlgorithms(list string, options pathfinding.PathfindingOptions) ([]pathfinding.Algorithm, error) {
+	if list == "all" || list == "" {
+		var selected []pathfinding.Algorithm
+		for _, algorithm := range pathfinding.Algorithms() {
+			if supportsOptions(algorithm.Name, options) {
+				selected = append(selected, algorithm)
+			}
+		}
+		return selected, nil
+	}
+
+	var selected []pathfinding.Algorithm
+	for _, name := range strings.Split(list, ",") {
+		algorithm, apiErr := lookupAlgorithm(strings.TrimSpace(name))
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if !supportsOptions(algorithm.Name, options) {
+			return nil, fmt.Errorf("%s does not support breaking or placing blocks", algorithm.Name)
+		}
+		selected = append(selected, algorithm)
+	}
+	return selected, nil
+}
+
+func benchCases(list string, seed int64, random int) ([]bench.Case, error) {
+	var cases []bench.Case
+
+	if list != "" {
+		var ids []string
+		if list != "all" {
+			for _, id := range strings.Split(list, ",") {
+				ids = append(ids, strings.TrimSpace(id))
+			}
+		}
+
+		scenarioCases, err := bench.ScenarioCases(ids)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, scenarioCases...)
+	}
+
+	randomCases, err := bench.RandomCases(seed, random)
+	if err != nil {
+		return nil, err
+	}
+	return append(cases, randomCases...), nil
+}
+
+func printBenchResult(r bench.Result) {
+	if !r.Found {
+		fmt.Fprintf(os.Stderr, "%-24s %-14s %s\n", r.Case, r.Algorithm, r.Error)
+		return
+	}
+
+	ratio := "-"
+	if r.OptimalityRatio != nil {
+		ratio = fmt.Sprintf("%.3f", *r.OptimalityRatio)
+	}
+	fmt.Fprintf(os.Stderr, "%-24s %-14s p50 %10.1fµs  p90 %10.1fµs  nodes %7d  cost %8.2f  ratio %s\n",
+		r.Case, r.Algorithm, r.TimeP50, r.TimeP90, r.NodesExplored, r.TotalCost, ratio)
+}
+
+func writeOutput(name string, write func(io.Writer) error) error {
+	if name == "" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
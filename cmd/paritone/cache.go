@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/resultcache"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+type searchResult struct {
+	pathfinding.PathfindingResult
+	cached bool
+}
+
+func cacheKey(algorithm pathfinding.Algorithm, start, goal pathfinding.Point, gameWorld *world.World, options pathfinding.PathfindingOptions) (resultcache.Key, bool) {
+	if !resultCache.Enabled() || options.Progress != nil {
+		return resultcache.Key{}, false
+	}
+	return resultcache.NewKey(gameWorld.Hash(), algorithm.Name, start, goal, options), true
+}
+
+func cacheable(result pathfinding.PathfindingResult) bool {
+	return result.Err == nil || errors.Is(result.Err, pathfinding.ErrNoPath)
+}
+
+func cacheOutcome(cached bool) string {
+	if cached {
+		return "hit"
+	}
+	return "miss"
+}
+
+func invalidateResults(r *http.Request, worldHash uint64) {
+	if removed := resultCache.InvalidateWorld(worldHash); removed > 0 {
+		cacheInvalidations.Add(float64(removed))
+		loggerFrom(r.Context()).Debug("invalidated cached results", "world", r.PathValue("id"), "results", removed)
+	}
+}
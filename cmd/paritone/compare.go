@@ -0,0 +1,178 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+func compareAlgorithmsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	req := newPathRequest()
+	if err := decodeJSON(r, r.Body, &req); err != nil {
+		writeError(w, invalidRequest(err))
+		return
+	}
+
+	loggerFrom(r.Context()).Debug("comparison request", "request", describeRequest(req))
+
+	search, err := preparePathSearch(r.Context(), req, cfg.SearchTimeout.Duration, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer search.release()
+
+	response := compareAlgorithms(search.start, search.goal, search.world, comparisonAlgorithms(req), search.options, nil)
+
+	annotate(w,
+		slog.Int("algorithms", len(response.Results)),
+		slog.Int("unsolved", len(response.Summary.Unsolved)),
+		slog.String("outcome", "compared"))
+	loggerFrom(r.Context()).Debug("comparison ranking", "byCost", response.Summary.ByCost)
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func comparisonAlgorithms(req api.PathRequest) []pathfinding.Algorithm {
+	var selected []pathfinding.Algorithm
+
+	if len(req.Algorithms) == 0 {
+		options := pathOptions(req)
+		for _, algorithm := range pathfinding.Algorithms() {
+			if supportsOptions(algorithm.Name, options) {
+				selected = append(selected, algorithm)
+			}
+		}
+		return selected
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range req.Algorithms {
+		algorithm, ok := pathfinding.LookupAlgorithm(name)
+		if !ok || seen[algorithm.Name] {
+			continue
+		}
+		seen[algorithm.Name] = true
+		selected = append(selected, algorithm)
+	}
+
+	return selected
+}
+
+func compareAlgorithms(start, goal pathfinding.Point, gameWorld *world.World, selected []pathfinding.Algorithm, options pathfinding.PathfindingOptions, onProgress func(algorithm string, progress pathfinding.SearchProgress)) api.ComparisonResponse {
+	results := make([]api.AlgorithmComparison, len(selected))
+
+	extra := acquireExtraSearchSlots(len(selected) - 1)
+	defer func() {
+		for i := 0; i < extra; i++ {
+			releaseSearchSlot()
+		}
+	}()
+	running := make(chan struct{}, extra+1)
+
+	var wg sync.WaitGroup
+	for i, algorithm := range selected {
+		wg.Add(1)
+		go func(i int, algorithm pathfinding.Algorithm) {
+			defer wg.Done()
+			running <- struct{}{}
+			defer func() { <-running }()
+
+			algorithmOptions := options
+			if onProgress != nil {
+				algorithmOptions.Progress = func(progress pathfinding.SearchProgress) {
+					onProgress(algorithm.Name, progress)
+				}
+			}
+
+			result := runAlgorithm(algorithm, start, goal, gameWorld, algorithmOptions)
+
+			results[i] = api.AlgorithmComparison{
+				Algorithm:             algorithm.Name,
+				Label:                 algorithm.Label,
+				Optimal:               algorithm.Optimal,
+				Found:                 len(result.Path) > 0,
+				Path:                  result.Path,
+				PathLength:            len(result.Path),
+				TotalCost:             result.TotalCost,
+				NodesExplored:         result.NodesExplored,
+				ComputationTime:       result.ComputationTime.Milliseconds(),
+				ComputationTimeMicros: result.ComputationTime.Microseconds(),
+				MaxMemoryUsed:         result.MaxMemoryUsed,
+				Cached:                result.cached,
+			}
+
+			if result.Err != nil {
+				results[i].Error = searchError(algorithm, result.Err)
+			}
+		}(i, algorithm)
+	}
+	wg.Wait()
+
+	return api.ComparisonResponse{
+		Results: results,
+		Summary: summariseComparison(results),
+	}
+}
+
+func summariseComparison(results []api.AlgorithmComparison) api.ComparisonSummary {
+	var summary api.ComparisonSummary
+	var solved []api.AlgorithmComparison
+
+	for _, result := range results {
+		if !result.Found {
+			summary.Unsolved = append(summary.Unsolved, result.Algorithm)
+			continue
+		}
+		solved = append(solved, result)
+
+		if result.Optimal && (summary.BestOptimalCost == nil || result.TotalCost < *summary.BestOptimalCost) {
+			cost := result.TotalCost
+			summary.BestOptimalCost = &cost
+		}
+	}
+
+	if best := summary.BestOptimalCost; best != nil {
+		for i := range results {
+			if !results[i].Found {
+				continue
+			}
+
+			gap := 0.0
+			if *best > 0 {
+				gap = (results[i].TotalCost - *best) / *best
+			}
+			results[i].OptimalityGap = &gap
+		}
+	}
+
+	rank := func(less func(a, b api.AlgorithmComparison) bool) []string {
+		ranked := append([]api.AlgorithmComparison(nil), solved...)
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return less(ranked[i], ranked[j])
+		})
+
+		names := make([]string, len(ranked))
+		for i, result := range ranked {
+			names[i] = result.Algorithm
+		}
+		return names
+	}
+
+	summary.ByCost = rank(func(a, b api.AlgorithmComparison) bool { return a.TotalCost < b.TotalCost })
+	summary.ByNodesExplored = rank(func(a, b api.AlgorithmComparison) bool { return a.NodesExplored < b.NodesExplored })
+	summary.ByTime = rank(func(a, b api.AlgorithmComparison) bool { return a.ComputationTimeMicros < b.ComputationTimeMicros })
+	summary.ByMemory = rank(func(a, b api.AlgorithmComparison) bool { return a.MaxMemoryUsed < b.MaxMemoryUsed })
+
+	return summary
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/internal/jobs"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/session"
+	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+const maxCoordinate = 30_000_000
+
+func newAPIError(status int, code, format string, args ...interface{}) *api.Error {
+	return &api.Error{
+		Status:  status,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+var errMethodNotAllowed = newAPIError(http.StatusMethodNotAllowed, api.CodeMethodNotAllowed, "Method not allowed")
+
+func invalidRequest(err error) *api.Error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return newAPIError(http.StatusRequestEntityTooLarge, api.CodeRequestTooLarge,
+			"request body exceeds the limit of %d bytes", tooLarge.Limit).
+			With("limit", "maxBodyBytes").With("limitValue", tooLarge.Limit)
+	}
+	return newAPIError(http.StatusBadRequest, api.CodeInvalidRequest, "%s", err.Error())
+}
+
+func toAPIError(err error) *api.Error {
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	switch {
+	case errors.Is(err, errWorldTooLarge):
+		return newAPIError(http.StatusRequestEntityTooLarge, api.CodeWorldTooLarge, "%s", err.Error())
+	case errors.Is(err, session.ErrNotFound), errors.Is(err, jobs.ErrNotFound):
+		return newAPIError(http.StatusNotFound, api.CodeNotFound, "%s", err.Error())
+	case errors.Is(err, session.ErrExists):
+		return newAPIError(http.StatusConflict, api.CodeConflict, "%s", err.Error())
+	case errors.Is(err, session.ErrStoreFull):
+		return newAPIError(http.StatusInsufficientStorage, api.CodeStoreFull, "%s", err.Error())
+	case errors.Is(err, jobs.ErrQueueFull):
+		return newAPIError(http.StatusServiceUnavailable, api.CodeQueueFull, "%s", err.Error())
+	case errors.Is(err, jobs.ErrClosed):
+		return newAPIError(http.StatusServiceUnavailable, api.CodeUnavailable, "%s", err.Error())
+	}
+
+	return invalidRequest(err)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr := toAPIError(err)
+
+	annotate(w, slog.String("outcome", apiErr.Code))
+
+	if apiErr.Code == api.CodeQueueFull {
+		w.Header().Set("Retry-After", "5")
+	} else if retryAfter, ok := apiErr.Details["retryAfter"].(int); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(apiErr.Status)
+	if err := json.NewEncoder(w).Encode(api.ErrorResponse{Error: apiErr}); err != nil {
+		logger.Warn("encoding error response", "error", err)
+	}
+}
+
+func validatePathRequest(req api.PathRequest) *api.Error {
+	coordinates := []struct {
+		name  string
+		value int
+	}{
+		{"startX", req.StartX}, {"startY", req.StartY}, {"startZ", req.StartZ},
+		{"endX", req.EndX}, {"endY", req.EndY}, {"endZ", req.EndZ},
+	}
+	for _, c := range coordinates {
+		if c.value < -maxCoordinate || c.value > maxCoordinate {
+			return newAPIError(http.StatusBadRequest, api.CodeInvalidCoordinates,
+				"%s must be between %d and %d", c.name, -maxCoordinate, maxCoordinate).
+				With("field", c.name).With("value", c.value)
+		}
+	}
+
+	if err := validateBudgets(req); err != nil {
+		return err
+	}
+
+	options := pathOptions(req)
+	names := req.Algorithms
+	if req.Algorithm != "" {
+		names = append([]string{req.Algorithm}, names...)
+	}
+
+	for _, name := range names {
+		if _, err := lookupAlgorithm(name); err != nil {
+			return err
+		}
+		if !supportsOptions(name, options) {
+			return newAPIError(http.StatusBadRequest, api.CodeInvalidOptions,
+				"jump point search does not support breaking or placing blocks").
+				With("algorithm", name)
+		}
+	}
+
+	return nil
+}
+
+func supportsOptions(algorithm string, options pathfinding.PathfindingOptions) bool {
+	return algorithm != "jps" || !(options.AllowBreaking || options.AllowPlacing)
+}
+
+func lookupAlgorithm(name string) (pathfinding.Algorithm, *api.Error) {
+	algorithm, ok := pathfinding.LookupAlgorithm(name)
+	if !ok {
+		supported := pathfinding.AlgorithmNames()
+		return pathfinding.Algorithm{}, newAPIError(http.StatusBadRequest, api.CodeUnknownAlgorithm,
+			"unknown algorithm %q, expected one of %s", name, strings.Join(supported, ", ")).
+			With("algorithm", name).With("supported", supported)
+	}
+	return algorithm, nil
+}
+
+func outOfBoundsError(code, name string, p pathfinding.Point, gameWorld *world.World) *api.Error {
+	minPoint, maxPoint := gameWorld.Bounds()
+	return newAPIError(http.StatusUnprocessableEntity, code, "%s %v lies outside the world", name, p).
+		With("point", p).With("min", minPoint).With("max", maxPoint)
+}
+
+func searchError(algorithm pathfinding.Algorithm, err error) *api.Error {
+	var apiErr *api.Error
+
+	switch {
+	case errors.Is(err, pathfinding.ErrStartBlocked):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeStartNotWalkable, "%s", err.Error())
+	case errors.Is(err, pathfinding.ErrGoalBlocked):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeGoalNotWalkable, "%s", err.Error())
+	case errors.Is(err, pathfinding.ErrIterationLimit), errors.Is(err, pathfinding.ErrMemoryLimit):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeBudgetExceeded, "%s", err.Error())
+	case errors.Is(err, pathfinding.ErrNegativeCycle):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeNegativeCycle, "%s", err.Error())
+	case errors.Is(err, pathfinding.ErrCancelled) && errors.Is(err, context.DeadlineExceeded):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeBudgetExceeded,
+			"search exceeded the %s time limit", cfg.SearchTimeout).
+			With("limit", "searchTimeout").With("limitValue", cfg.SearchTimeout.String())
+	case errors.Is(err, pathfinding.ErrCancelled):
+		apiErr = newAPIError(http.StatusServiceUnavailable, api.CodeUnavailable, "%s", err.Error())
+	case errors.Is(err, pathfinding.ErrNoPath):
+		apiErr = newAPIError(http.StatusUnprocessableEntity, api.CodeNoPath, "No path found")
+	default:
+		apiErr = newAPIError(http.StatusInternalServerError, api.CodeInternal, "%s", err.Error())
+	}
+
+	if algorithm.Name != "" {
+		apiErr = apiErr.With("algorithm", algorithm.Name)
+	}
+
+	var details *pathfinding.SearchError
+	if errors.As(err, &details) {
+		apiErr = apiErr.With("nodesExplored", details.NodesExplored)
+		if details.Point != nil {
+			apiErr = apiErr.With("point", *details.Point)
+		}
+		if details.Block != "" {
+			apiErr = apiErr.With("block", details.Block)
+		}
+		if details.Limit != "" {
+			apiErr = apiErr.With("limit", details.Limit).With("limitValue", details.LimitValue)
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/internal/export"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+func negotiateFormat(r *http.Request) (*export.Format, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		if name == "json" {
+			return nil, nil
+		}
+		format, ok := export.Lookup(name)
+		if !ok {
+			supported := append([]string{"json"}, export.Names()...)
+			return nil, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest,
+				"unknown format %q, expected one of %s", name, strings.Join(supported, ", ")).
+				With("field", "format").With("supported", supported)
+		}
+		return &format, nil
+	}
+
+	for _, mediaType := range acceptedMediaTypes(r.Header.Get("Accept")) {
+		if mediaType == "application/json" || mediaType == "*/*" {
+			return nil, nil
+		}
+		if format, ok := export.ForMediaType(mediaType); ok {
+			return &format, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func acceptedMediaTypes(header string) []string {
+	type accepted struct {
+		mediaType string
+		quality   float64
+	}
+
+	var entries []accepted
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		entries = append(entries, accepted{mediaType, quality})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].quality > entries[j].quality })
+
+	mediaTypes := make([]string, len(entries))
+	for i, e := range entries {
+		mediaTypes[i] = e.mediaType
+	}
+	return mediaTypes
+}
+
+func exportPath(response api.PathResponse, algorithm string, options pathfinding.PathfindingOptions) export.Path {
+	return export.Path{
+		Algorithm:     algorithm,
+		Points:        response.Path,
+		BlocksBroken:  response.BlocksBroken,
+		BlocksPlaced:  response.BlocksPlaced,
+		TotalCost:     response.TotalCost,
+		AllowBreaking: options.AllowBreaking,
+		AllowPlacing:  options.AllowPlacing,
+	}
+}
+
+func writeExport(w http.ResponseWriter, r *http.Request, format export.Format, path export.Path) {
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"path.%s\"", format.Extension))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if err := format.Write(w, path); err != nil {
+		loggerFrom(r.Context()).Warn("writing export", "format", format.Name, "error", err)
+	}
+}
+
+func exportCommand(args []string) int {
+	fs := flag.NewFlagSet("paritone export", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: paritone export [flags] [response.json]\n\n")
+		fmt.Fprintf(fs.Output(), "Converts a find-path JSON response, read from the file or standard input, to another format.\n\n")
+		fs.PrintDefaults()
+	}
+	formatName := fs.String("format", "csv", "output format: "+strings.Join(export.Names(), ", "))
+	output := fs.String("o", "", "write to this file instead of standard output")
+	algorithm := fs.String("algorithm", "", "algorithm name recorded in formats that carry one")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	format, ok := export.Lookup(*formatName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "paritone export: unknown format %q, expected one of %s\n", *formatName, strings.Join(export.Names(), ", "))
+		return 2
+	}
+
+	var in io.Reader = os.Stdin
+	if fs.NArg() > 0 {
+		file, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone export: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var response api.PathResponse
+	if err := json.NewDecoder(in).Decode(&response); err != nil {
+		fmt.Fprintf(os.Stderr, "paritone export: reading path response: %v\n", err)
+		return 1
+	}
+
+	return writeExportFile(*output, format, exportPath(response, *algorithm, pathfinding.PathfindingOptions{}), "paritone export")
+}
+
+func writeExportFile(name string, format export.Format, path export.Path, command string) int {
+	err := writeOutput(name, func(w io.Writer) error {
+		return format.Write(w, path)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: writing %s: %v\n", command, format.Name, err)
+		return 1
+	}
+	return 0
+}
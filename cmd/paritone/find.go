@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
+	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+type pointFlag struct {
+	point pathfinding.Point
+	set   bool
+}
+
+func (f *pointFlag) String() string {
+	if !f.set {
+		return ""
+	}
+	return fmt.Sprintf("%d,%d,%d", f.point.X, f.point.Y, f.point.Z)
+}
+
+func (f *pointFlag) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("expected x,y,z")
+	}
+
+	var coordinates [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("expected x,y,z: %v", err)
+		}
+		coordinates[i] = v
+	}
+
+	f.point = pathfinding.Point{X: coordinates[0], Y: coordinates[1], Z: coordinates[2]}
+	f.set = true
+	return nil
+}
+
+type worldFlags struct {
+	scenario string
+	file     string
+	seed     int64
+	seedSet  bool
+}
+
+func (w *worldFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&w.scenario, "scenario", "", "use a built-in scenario: "+strings.Join(scenarios.IDs(), ", "))
+	fs.StringVar(&w.file, "world", "", "load a JSON world file with min, max, blocks and regions, as in a request's world field")
+	fs.Func("seed", "generate terrain from this seed", func(value string) error {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		w.seed, w.seedSet = seed, true
+		return nil
+	})
+}
+
+func (w *worldFlags) spec() (*api.WorldSpec, error) {
+	sources := 0
+	for _, set := range []bool{w.scenario != "", w.file != "", w.seedSet} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("-scenario, -world and -seed cannot be combined")
+	}
+
+	switch {
+	case w.scenario != "":
+		if _, ok := scenarios.Get(w.scenario); !ok {
+			return nil, fmt.Errorf("unknown scenario %q, expected one of %s", w.scenario, strings.Join(scenarios.IDs(), ", "))
+		}
+		return &api.WorldSpec{Scenario: w.scenario}, nil
+	case w.seedSet:
+		seed := w.seed
+		return &api.WorldSpec{Seed: &seed}, nil
+	case w.file != "":
+		return readWorldFile(w.file)
+	}
+	return nil, nil
+}
+
+func readWorldFile(name string) (*api.WorldSpec, error) {
+	var in io.Reader = os.Stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var spec api.WorldSpec
+	if err := json.NewDecoder(in).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("reading world %s: %v", name, err)
+	}
+	spec.ID = ""
+	return &spec, nil
+}
+
+func defaultEndpoints(spec *api.WorldSpec) (pathfinding.Point, pathfinding.Point, error) {
+	if spec == nil {
+		return pathfinding.Point{}, pathfinding.Point{}, fmt.Errorf("-start and -goal are required without -scenario, -world or -seed")
+	}
+
+	if spec.Scenario != "" {
+		scenario, _ := scenarios.Get(spec.Scenario)
+		return scenario.Start, scenario.Goal, nil
+	}
+
+	built, err := worldFromSpec(*spec)
+	if err != nil {
+		return pathfinding.Point{}, pathfinding.Point{}, err
+	}
+	start, goal, ok := world.SpawnPoints(built)
+	if !ok {
+		return pathfinding.Point{}, pathfinding.Point{}, fmt.Errorf("world has no standable spawn points, give -start and -goal")
+	}
+	return start, goal, nil
+}
+
+func findCommand(args []string) int {
+	fs := flag.NewFlagSet("paritone find", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: paritone find [flags]\n\n")
+		fmt.Fprintf(fs.Output(), "Runs one path query without a server. Exits 1 when no path is found and 2 on invalid input.\n\n")
+		fs.PrintDefaults()
+	}
+
+	var source worldFlags
+	source.register(fs)
+
+	var start, goal pointFlag
+	fs.Var(&start, "start", "start point as x,y,z (default: the scenario's, or spawn points for -seed and -world)")
+	fs.Var(&goal, "goal", "goal point as x,y,z")
+
+	var (
+		algorithmName    = fs.String("algorithm", cfg.Defaults.Algorithm, "algorithm: "+strings.Join(pathfinding.AlgorithmNames(), ", "))
+		allowBreaking    = fs.Bool("allow-breaking", cfg.Defaults.AllowBreaking, "allow breaking blocks")
+		allowPlacing     = fs.Bool("allow-placing", cfg.Defaults.AllowPlacing, "allow placing blocks")
+		avoidWater       = fs.Bool("avoid-water", cfg.Defaults.AvoidWater, "avoid water")
+		minimiseHeight   = fs.Bool("minimise-height", cfg.Defaults.MinimiseVertical, "minimise vertical movement")
+		jumpPoints       = fs.Bool("jump-points", false, "enable jump point optimisation")
+		heuristicWeight  = fs.Float64("heuristic-weight", 0, "heuristic weight, 0 for the algorithm's default")
+		maxIterations    = fs.Int("max-iterations", cfg.Defaults.MaxIterations, "iteration limit for iterative algorithms such as IDA*")
+		maxNodes         = fs.Int("max-nodes", 0, fmt.Sprintf("stop after expanding this many nodes (default %d)", cfg.MaxSearchNodes))
+		maxFrontier      = fs.Int("max-frontier", 0, fmt.Sprintf("stop when the open set grows past this size (default %d)", cfg.MaxSearchFrontier))
+		timeout          = fs.Duration("timeout", cfg.SearchTimeout.Duration, "give up after this long, 0 for no limit")
+		progressInterval = fs.Int("progress", 0, "print search progress to standard error every this many nodes")
+		progressFrontier = fs.Int("progress-frontier", 0, "include up to this many frontier points in progress lines")
+		verbose          = fs.Bool("v", false, "log search diagnostics to standard error")
+		jsonOutput       = fs.Bool("json", false, "print the API's JSON response instead of a summary")
+		showPath         = fs.Bool("path", false, "list every point of the path in the summary")
+		showActions      = fs.Bool("actions", false, "list the planned actions in the summary")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "paritone find: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+
+	cfg.MaxWorldVolume, cfg.MaxWorldBlocks = math.MaxInt, math.MaxInt
+	cfg.MaxSearchIterations = max(cfg.MaxSearchIterations, *maxIterations)
+	cfg.MaxSearchNodes = max(cfg.MaxSearchNodes, *maxNodes)
+	cfg.MaxSearchFrontier = max(cfg.MaxSearchFrontier, *maxFrontier)
+	cfg.SearchTimeout.Duration = *timeout
+
+	spec, err := source.spec()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone find: %v\n", err)
+		return 2
+	}
+
+	if !start.set || !goal.set {
+		defaultStart, defaultGoal, err := defaultEndpoints(spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone find: %v\n", err)
+			return 2
+		}
+		if !start.set {
+			start.point = defaultStart
+		}
+		if !goal.set {
+			goal.point = defaultGoal
+		}
+	}
+
+	req := api.PathRequest{
+		StartX: start.point.X, StartY: start.point.Y, StartZ: start.point.Z,
+		EndX: goal.point.X, EndY: goal.point.Y, EndZ: goal.point.Z,
+		Algorithm:     *algorithmName,
+		AllowBreaking: allowBreaking,
+		AllowPlacing:  allowPlacing,
+		AvoidWater:    avoidWater,
+		MinVertical:   minimiseHeight,
+		MaxIterations: *maxIterations,
+		MaxNodes:      *maxNodes,
+		MaxFrontier:   *maxFrontier,
+		World:         spec,
+	}
+
+	ctx := context.Background()
+	if *verbose {
+		verboseConfig := cfg
+		verboseConfig.LogLevel = "debug"
+		logger = newLogger(verboseConfig, os.Stderr)
+	} else {
+		logger = newLogger(cfg, io.Discard)
+	}
+
+	search, err := preparePathSearch(ctx, req, *timeout, true)
+	if err != nil {
+		return reportFindError(toAPIError(err), *jsonOutput)
+	}
+	defer search.release()
+
+	search.options.JumpPointOptimisation = *jumpPoints
+	search.options.HeuristicWeight = *heuristicWeight
+	search.options.ProgressFrontierLimit = *progressFrontier
+	if *progressInterval > 0 {
+		search.options.ProgressInterval = *progressInterval
+		search.options.Progress = func(progress pathfinding.SearchProgress) {
+			fmt.Fprintf(os.Stderr, "progress: %d nodes, frontier %d, best f %.2f, at %d,%d,%d, %s\n",
+				progress.NodesExplored, progress.FrontierSize, progress.BestFScore,
+				progress.Current.X, progress.Current.Y, progress.Current.Z, progress.Elapsed.Round(time.Microsecond))
+		}
+	}
+
+	result := search.run()
+	if result.Err != nil {
+		return reportFindError(searchError(search.algorithm, result.Err), *jsonOutput)
+	}
+
+	response := newPathResponse(result, search.world)
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(response); err != nil {
+			fmt.Fprintf(os.Stderr, "paritone find: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	printFindSummary(os.Stdout, search, req, result, response, *showPath, *showActions)
+	return 0
+}
+
+func reportFindError(apiErr *api.Error, jsonOutput bool) int {
+	status := 1
+	switch apiErr.Code {
+	case api.CodeStartNotWalkable, api.CodeGoalNotWalkable, api.CodeStartOutOfBounds, api.CodeGoalOutOfBounds,
+		api.CodeInvalidOptions, api.CodeInvalidCoordinates, api.CodeUnknownAlgorithm:
+		status = 2
+	default:
+		if apiErr.Status == http.StatusBadRequest || apiErr.Status == http.StatusRequestEntityTooLarge {
+			status = 2
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(api.ErrorResponse{Error: apiErr})
+		return status
+	}
+
+	fmt.Fprintf(os.Stderr, "paritone find: %s (%s)\n", apiErr.Message, apiErr.Code)
+	return status
+}
+
+func printFindSummary(w io.Writer, search *pathSearch, req api.PathRequest, result searchResult, response api.PathResponse, showPath, showActions bool) {
+	fmt.Fprintf(w, "Path found by %s in %s\n", search.algorithm.Label, result.ComputationTime.Round(time.Microsecond))
+	fmt.Fprintf(w, "  World:            %s\n", describeWorldSpec(req.World))
+	fmt.Fprintf(w, "  Start:            %d,%d,%d\n", search.start.X, search.start.Y, search.start.Z)
+	fmt.Fprintf(w, "  Goal:             %d,%d,%d\n", search.goal.X, search.goal.Y, search.goal.Z)
+	fmt.Fprintf(w, "  Path length:      %d blocks\n", len(result.Path))
+	fmt.Fprintf(w, "  Total cost:       %.2f\n", result.TotalCost)
+	fmt.Fprintf(w, "  Nodes explored:   %d\n", result.NodesExplored)
+	fmt.Fprintf(w, "  Peak memory:      %d nodes\n", result.MaxMemoryUsed)
+	fmt.Fprintf(w, "  Blocks broken:    %d\n", len(result.BlocksBroken))
+	fmt.Fprintf(w, "  Blocks placed:    %d\n", len(result.BlocksPlaced))
+	fmt.Fprintf(w, "  Water crossed:    %d\n", result.WaterCrossed)
+	fmt.Fprintf(w, "  Vertical change:  %d\n", result.VerticalChange)
+	fmt.Fprintf(w, "  Estimated time:   %.2fs\n", response.EstimatedTime)
+
+	var planned time.Duration
+	counts := make(map[string]int)
+	for _, action := range response.Actions {
+		planned += time.Duration(action.Duration) * time.Millisecond
+		counts[action.Type]++
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	summary := make([]string, len(types))
+	for i, t := range types {
+		summary[i] = fmt.Sprintf("%d %s", counts[t], t)
+	}
+	fmt.Fprintf(w, "  Actions:          %s (%s)\n", strings.Join(summary, ", "), planned)
+
+	if showPath {
+		fmt.Fprintln(w, "\nPath:")
+		for i, p := range result.Path {
+			fmt.Fprintf(w, "  %4d  %d,%d,%d\n", i, p.X, p.Y, p.Z)
+		}
+	}
+
+	if showActions {
+		fmt.Fprintln(w, "\nActions:")
+		for i, a := range response.Actions {
+			detail := ""
+			if a.Block != "" {
+				detail = " " + a.Block
+			}
+			if a.Face != "" {
+				detail += " on " + a.Face
+			}
+			fmt.Fprintf(w, "  %4d  %-9s %d,%d,%d -> %d,%d,%d%s (%dms)\n", i, a.Type,
+				a.From.X, a.From.Y, a.From.Z, a.To.X, a.To.Y, a.To.Z, detail, a.Duration)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+func withBodyLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, int64(cfg.MaxBodyBytes))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rateLimited(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if allowed, wait := clientLimiter.Allow(clientKey(r), time.Now()); !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			writeError(w, newAPIError(http.StatusTooManyRequests, api.CodeRateLimited,
+				"too many requests, retry in %ds", retryAfter).
+				With("retryAfter", retryAfter).
+				With("limit", cfg.RateLimit).
+				With("burst", cfg.RateBurst))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func clientKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func validateBudgets(req api.PathRequest) *api.Error {
+	budgets := []struct {
+		name  string
+		value int
+		limit int
+		cap   string
+	}{
+		{"maxIterations", req.MaxIterations, cfg.MaxSearchIterations, "maxSearchIterations"},
+		{"maxNodes", req.MaxNodes, cfg.MaxSearchNodes, "maxSearchNodes"},
+		{"maxFrontier", req.MaxFrontier, cfg.MaxSearchFrontier, "maxSearchFrontier"},
+	}
+
+	for _, b := range budgets {
+		if b.value < 0 {
+			return newAPIError(http.StatusBadRequest, api.CodeInvalidOptions, "%s must not be negative", b.name).
+				With("field", b.name).With("value", b.value)
+		}
+		if b.value > b.limit {
+			return newAPIError(http.StatusRequestEntityTooLarge, api.CodeBudgetTooLarge,
+				"%s %d exceeds the server limit of %d", b.name, b.value, b.limit).
+				With("field", b.name).With("value", b.value).
+				With("limit", b.cap).With("limitValue", b.limit)
+		}
+	}
+
+	return nil
+}
+
+func budgetOrLimit(requested, limit int) int {
+	if requested > 0 {
+		return requested
+	}
+	return limit
+}
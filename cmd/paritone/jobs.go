@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/jobs"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+var jobManager = jobs.NewManager(jobs.Config{
+	Workers:     4,
+	QueueSize:   64,
+	Retention:   15 * time.Minute,
+	MaxRetained: 256,
+})
+
+func newJobProgress(algorithm string, progress pathfinding.SearchProgress) api.JobProgress {
+	return api.JobProgress{
+		Algorithm:     algorithm,
+		NodesExplored: progress.NodesExplored,
+		FrontierSize:  progress.FrontierSize,
+		BestFScore:    finiteOrNil(progress.BestFScore),
+		Iteration:     progress.Iteration,
+		Current:       progress.Current,
+		Elapsed:       progress.Elapsed.Milliseconds(),
+	}
+}
+
+func jobsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, jobManager.List())
+	case "POST":
+		req := api.JobRequest{Request: newPathRequest()}
+		if err := decodeJSON(r, r.Body, &req); err != nil {
+			writeError(w, invalidRequest(err))
+			return
+		}
+
+		if req.Type == "" {
+			req.Type = "path"
+		}
+
+		task, err := jobTask(req, loggerFrom(r.Context()))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if spec := req.Request.World; spec != nil && spec.ID != "" {
+			if _, err := worldStore.Get(spec.ID); err != nil {
+				writeError(w, err)
+				return
+			}
+		}
+
+		snapshot, err := jobManager.Submit(req.Type, task)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		loggerFrom(r.Context()).Info("queued job", "job", snapshot.ID, "type", req.Type, "request", describeRequest(req.Request))
+		annotate(w, slog.String("job", snapshot.ID), slog.String("outcome", "queued"))
+
+		w.Header().Set("Location", "/api/jobs/"+snapshot.ID)
+		writeJSON(w, http.StatusAccepted, snapshot)
+	default:
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+func jobHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case "GET":
+		snapshot, err := jobManager.Get(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, snapshot)
+	case "DELETE":
+		snapshot, err := jobManager.Cancel(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		loggerFrom(r.Context()).Info("cancelled job", "job", id, "status", snapshot.Status)
+		writeJSON(w, http.StatusOK, snapshot)
+	default:
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+func jobTask(req api.JobRequest, requestLogger *slog.Logger) (jobs.Task, error) {
+	pathReq := req.Request
+
+	if req.Type != "path" && req.Type != "compare" {
+		return nil, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest,
+			"unknown job type %q, expected \"path\" or \"compare\"", req.Type).With("type", req.Type)
+	}
+
+	if err := validatePathRequest(pathReq); err != nil {
+		return nil, err
+	}
+
+	if req.Type == "path" {
+		return func(ctx context.Context, report func(interface{})) (interface{}, error) {
+			search, err := preparePathSearch(contextWithLogger(ctx, requestLogger), pathReq, 0, true)
+			if err != nil {
+				return nil, err
+			}
+			defer search.release()
+
+			search.options.Progress = func(progress pathfinding.SearchProgress) {
+				report(newJobProgress(search.algorithm.Name, progress))
+			}
+
+			result := search.run()
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if result.Err != nil {
+				return nil, searchError(search.algorithm, result.Err)
+			}
+
+			return newPathResponse(result, search.world), nil
+		}, nil
+	}
+
+	selected := comparisonAlgorithms(pathReq)
+
+	return func(ctx context.Context, report func(interface{})) (interface{}, error) {
+		search, err := preparePathSearch(contextWithLogger(ctx, requestLogger), pathReq, 0, true)
+		if err != nil {
+			return nil, err
+		}
+		defer search.release()
+
+		var mu sync.Mutex
+		latest := make(map[string]api.JobProgress)
+
+		response := compareAlgorithms(search.start, search.goal, search.world, selected, search.options, func(algorithm string, progress pathfinding.SearchProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			latest[algorithm] = newJobProgress(algorithm, progress)
+
+			snapshot := make(map[string]api.JobProgress, len(latest))
+			for name, p := range latest {
+				snapshot[name] = p
+			}
+			report(snapshot)
+		})
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return response, nil
+	}, nil
+}
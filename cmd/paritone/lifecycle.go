@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+var (
+	serverCtx, cancelServerCtx = context.WithCancel(context.Background())
+
+	shuttingDown        atomic.Bool
+	drainedSearches     atomic.Int64
+	interruptedSearches atomic.Int64
+)
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, api.HealthResponse{
+		Status:           "ok",
+		SearchesInFlight: int(searchesInFlight.Value()),
+		SearchSlots:      cap(searchSlots),
+	})
+}
+
+func readyHandler(w http.ResponseWriter, r *http.Request) {
+	response := api.HealthResponse{
+		Status:           "ready",
+		SearchesInFlight: int(searchesInFlight.Value()),
+		SearchSlots:      cap(searchSlots),
+	}
+
+	if shuttingDown.Load() {
+		response.Status = "shutting_down"
+		writeJSON(w, http.StatusServiceUnavailable, response)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func recordShutdownOutcome(result pathfinding.PathfindingResult) {
+	if !shuttingDown.Load() {
+		return
+	}
+
+	if errors.Is(result.Err, pathfinding.ErrCancelled) {
+		interruptedSearches.Add(1)
+	} else {
+		drainedSearches.Add(1)
+	}
+}
+
+func serve(server *http.Server) {
+	server.BaseContext = func(net.Listener) context.Context { return serverCtx }
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		fatal("server stopped", err)
+	case <-signals.Done():
+		stop()
+	}
+
+	grace := cfg.ShutdownTimeout.Duration
+	shuttingDown.Store(true)
+	logger.Info("shutting down", "delay", cfg.ShutdownDelay.Duration, "gracePeriod", grace,
+		"searchesInFlight", int(searchesInFlight.Value()))
+
+	time.Sleep(cfg.ShutdownDelay.Duration)
+
+	graceCtx, cancelGrace := context.WithTimeout(context.Background(), grace)
+	defer cancelGrace()
+
+	interruptedJobs := make(chan int, 1)
+	go func() {
+		interruptedJobs <- jobManager.Shutdown(graceCtx)
+	}()
+
+	if err := server.Shutdown(graceCtx); err != nil {
+		logger.Warn("grace period expired, cancelling running searches",
+			"searchesInFlight", int(searchesInFlight.Value()))
+		cancelServerCtx()
+
+		closeCtx, cancelClose := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := server.Shutdown(closeCtx); err != nil {
+			server.Close()
+		}
+		cancelClose()
+	}
+
+	logger.Info("shutdown complete",
+		"drainedSearches", drainedSearches.Load(),
+		"interruptedSearches", interruptedSearches.Load(),
+		"interruptedJobs", <-interruptedJobs)
+}
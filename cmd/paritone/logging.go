@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/config"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+var logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+type loggerKey struct{}
+
+func newLogger(c config.Config, out io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{Level: c.Level()}
+
+	if c.LogFormat == "json" {
+		return slog.New(slog.NewJSONHandler(out, options))
+	}
+	return slog.New(slog.NewTextHandler(out, options))
+}
+
+func contextWithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return logger
+}
+
+func requestID(r *http.Request) string {
+	id := r.Header.Get(requestIDHeader)
+	if id == "" || len(id) > 128 {
+		return newRequestID()
+	}
+
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':') {
+			return newRequestID()
+		}
+	}
+	return id
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+type accessRecorder struct {
+	http.ResponseWriter
+
+	status int
+	bytes  int
+
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+func (rec *accessRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *accessRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytes += n
+	return n, err
+}
+
+func (rec *accessRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (rec *accessRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+func annotate(w http.ResponseWriter, attrs ...slog.Attr) {
+	rec, ok := w.(*accessRecorder)
+	if !ok {
+		return
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+next:
+	for _, attr := range attrs {
+		for i := range rec.attrs {
+			if rec.attrs[i].Key == attr.Key {
+				rec.attrs[i] = attr
+				continue next
+			}
+		}
+		rec.attrs = append(rec.attrs, attr)
+	}
+}
+
+func withRequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestID(r)
+		w.Header().Set(requestIDHeader, id)
+
+		requestLogger := logger.With("requestId", id)
+		r = r.WithContext(contextWithLogger(r.Context(), requestLogger))
+
+		rec := &accessRecorder{ResponseWriter: w}
+		started := time.Now()
+
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.status),
+			slog.Int("bytes", rec.bytes),
+			slog.Duration("duration", time.Since(started)),
+			slog.String("remote", r.RemoteAddr),
+		}
+
+		rec.mu.Lock()
+		attrs = append(attrs, rec.attrs...)
+		rec.mu.Unlock()
+
+		level := slog.LevelInfo
+		if rec.status >= 500 {
+			level = slog.LevelError
+		}
+		requestLogger.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
@@ -1,50 +1,56 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
-	"path/filepath"
+	"strconv"
+	"time"
 
+	"github.com/WillKirkmanM/paritone/frontend"
+	"github.com/WillKirkmanM/paritone/internal/config"
 	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/planner"
+	"github.com/WillKirkmanM/paritone/internal/ratelimit"
+	"github.com/WillKirkmanM/paritone/internal/resultcache"
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
 	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
 )
 
-type PathRequest struct {
-	StartX        int     `json:"startX"`
-	StartY        int     `json:"startY"`
-	StartZ        int     `json:"startZ"`
-	EndX          int     `json:"endX"`
-	EndY          int     `json:"endY"`
-	EndZ          int     `json:"endZ"`
-	Algorithm     string  `json:"algorithm"`
-	AllowBreaking bool    `json:"allowBreaking"`
-	AllowPlacing  bool    `json:"allowPlacing"`
-	AvoidWater    bool    `json:"avoidWater"`
-	MinVertical   bool    `json:"minimiseVertical"`
-}
+const (
+	maxGeneratedSize   = 128
+	maxGeneratedHeight = 64
+)
 
-type PathResponse struct {
-	Path            []pathfinding.Point `json:"path"`
-	Error           string              `json:"error,omitempty"`
-	ComputationTime int64               `json:"computationTime"`
-	NodesExplored   int                 `json:"nodesExplored"`
-	BlocksTraversed int                 `json:"blocksTraversed"`
-	BlocksBroken    []pathfinding.Point `json:"blocksBroken"`
-	BlocksPlaced    []pathfinding.Point `json:"blocksPlaced"`
-	WaterCrossed    int                 `json:"waterCrossed"`
-	VerticalChange  int                 `json:"verticalChange"`
-	EstimatedTime   float64             `json:"estimatedTime"`
-	TotalCost       float64             `json:"totalCost"`
-}
+var (
+	cfg           = config.Default()
+	searchSlots   = make(chan struct{}, cfg.MaxConcurrentSearches)
+	clientLimiter = ratelimit.NewLimiter(cfg.RateLimit, cfg.RateBurst)
+	resultCache   = resultcache.New(cfg.ResultCacheSize)
+)
+
+var errWorldTooLarge = errors.New("world too large")
 
 func enableCORS(handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		if cfg.AllowsAnyOrigin() {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Add("Vary", "Origin")
+			if origin := r.Header.Get("Origin"); origin != "" && cfg.AllowsOrigin(origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		}
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, "+requestIDHeader)
+		w.Header().Set("Access-Control-Expose-Headers", requestIDHeader)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -56,60 +62,227 @@ func enableCORS(handler http.HandlerFunc) http.HandlerFunc {
 
 func findPathHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	var req PathRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req := newPathRequest()
+	if err := decodeJSON(r, r.Body, &req); err != nil {
+		writeError(w, invalidRequest(err))
 		return
 	}
 
-	fmt.Printf("Received path request: %+v\n", req)
+	w.Header().Add("Vary", "Accept")
+	format, err := negotiateFormat(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	gameWorld := world.NewWorld()
+	loggerFrom(r.Context()).Debug("path request", "request", describeRequest(req))
+	annotate(w, slog.String("algorithm", req.Algorithm))
 
-	setupWorld(gameWorld, req)
+	search, err := preparePathSearch(r.Context(), req, cfg.SearchTimeout.Duration, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer search.release()
 
-	start := pathfinding.Point{X: req.StartX, Y: req.StartY, Z: req.StartZ}
-	goal := pathfinding.Point{X: req.EndX, Y: req.EndY, Z: req.EndZ}
+	result := search.run()
 
-	gameWorld.SetBlock(start, world.Block{
-		Type:      "air",
-		Walkable:  true,
-		Breakable: false,
-		MoveCost:  1.0,
-	})
+	annotate(w, slog.Int("nodesExplored", result.NodesExplored))
+	if resultCache.Enabled() {
+		annotate(w, slog.String("cache", cacheOutcome(result.cached)))
+		w.Header().Set("X-Cache", cacheOutcome(result.cached))
+	}
 
-	gameWorld.SetBlock(goal, world.Block{
-		Type:      "air",
-		Walkable:  true,
-		Breakable: false,
-		MoveCost:  1.0,
-	})
+	if result.Err != nil {
+		writeError(w, searchError(search.algorithm, result.Err))
+		return
+	}
+
+	annotate(w, slog.String("outcome", "found"), slog.Int("pathLength", len(result.Path)))
+	logPathStats(r.Context(), result.PathfindingResult)
 
-	options := pathfinding.PathfindingOptions{
-		AllowBreaking:  req.AllowBreaking,
-		AllowPlacing:   req.AllowPlacing,
-		AvoidWater:     req.AvoidWater,
-		MinimiseHeight: req.MinVertical,
+	response := newPathResponse(result, search.world)
+	if format != nil {
+		annotate(w, slog.String("format", format.Name))
+		writeExport(w, r, *format, exportPath(response, search.algorithm.Name, search.options))
+		return
 	}
 
-	var result pathfinding.PathfindingResult
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		loggerFrom(r.Context()).Warn("encoding response", "error", err)
+	}
+}
 
-	fmt.Printf("Finding path from %v to %v using %s with options %+v\n", start, goal, req.Algorithm, options)
+type pathSearch struct {
+	world     *world.World
+	release   func()
+	start     pathfinding.Point
+	goal      pathfinding.Point
+	algorithm pathfinding.Algorithm
+	options   pathfinding.PathfindingOptions
+}
 
-	switch req.Algorithm {
-	case "bfs":
-		result = pathfinding.FindPathBFSWithOptions(start, goal, gameWorld, options)
-	case "dijkstra":
-		result = pathfinding.FindPathDijkstraWithOptions(start, goal, gameWorld, options)
-	default:
-		result = pathfinding.FindPathWithOptions(start, goal, gameWorld, options)
+func preparePathSearch(ctx context.Context, req api.PathRequest, timeout time.Duration, waitForSlot bool) (*pathSearch, error) {
+	if err := validatePathRequest(req); err != nil {
+		return nil, err
+	}
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	if err := acquireSearchSlot(ctx, waitForSlot); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	gameWorld, releaseWorld, err := buildWorld(req)
+	if err != nil {
+		releaseSearchSlot()
+		cancel()
+		return nil, err
+	}
+
+	search := &pathSearch{
+		world: gameWorld,
+		release: func() {
+			releaseWorld()
+			releaseSearchSlot()
+			cancel()
+		},
+		start:     pathfinding.Point{X: req.StartX, Y: req.StartY, Z: req.StartZ},
+		goal:      pathfinding.Point{X: req.EndX, Y: req.EndY, Z: req.EndZ},
+		algorithm: algorithmFor(req.Algorithm),
+		options:   pathOptions(req),
+	}
+	search.options.Context = ctx
+	search.options.Logger = loggerFrom(ctx)
+
+	if err := pathfinding.CheckEndpoints(search.start, search.goal, gameWorld, search.options); err != nil {
+		search.release()
+		return nil, searchError(pathfinding.Algorithm{}, err)
+	}
+
+	return search, nil
+}
+
+func acquireSearchSlot(ctx context.Context, wait bool) error {
+	if !wait {
+		select {
+		case searchSlots <- struct{}{}:
+			return nil
+		default:
+			return newAPIError(http.StatusTooManyRequests, api.CodeServerBusy,
+				"all %d search slots are busy, retry shortly or submit a job", cap(searchSlots)).
+				With("retryAfter", 1).With("limit", "maxConcurrentSearches").With("limitValue", cap(searchSlots))
+		}
+	}
+
+	select {
+	case searchSlots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return newAPIError(http.StatusServiceUnavailable, api.CodeUnavailable,
+			"timed out waiting for one of %d search slots", cap(searchSlots))
+	}
+}
+
+func releaseSearchSlot() {
+	<-searchSlots
+}
+
+func acquireExtraSearchSlots(n int) int {
+	for acquired := 0; acquired < n; acquired++ {
+		select {
+		case searchSlots <- struct{}{}:
+		default:
+			return acquired
+		}
 	}
+	return n
+}
 
-	response := PathResponse{
+func newPathRequest() api.PathRequest {
+	return api.PathRequest{Algorithm: cfg.Defaults.Algorithm}
+}
+
+func (s *pathSearch) run() searchResult {
+	return runAlgorithm(s.algorithm, s.start, s.goal, s.world, s.options)
+}
+
+func runAlgorithm(algorithm pathfinding.Algorithm, start, goal pathfinding.Point, gameWorld *world.World, options pathfinding.PathfindingOptions) searchResult {
+	if options.Logger != nil {
+		options.Logger = options.Logger.With("algorithm", algorithm.Name)
+	}
+
+	key, useCache := cacheKey(algorithm, start, goal, gameWorld, options)
+	if useCache {
+		if cached, ok := resultCache.Get(key); ok {
+			cacheRequests.Inc("hit")
+			if options.Logger != nil {
+				options.Logger.Debug("result cache hit", "start", start, "goal", goal)
+			}
+			return searchResult{PathfindingResult: cached, cached: true}
+		}
+		cacheRequests.Inc("miss")
+	}
+
+	searchesInFlight.Inc()
+	defer searchesInFlight.Dec()
+
+	result := algorithm.Search(start, goal, gameWorld, options)
+	recordSearch(algorithm, gameWorld, result)
+	recordShutdownOutcome(result)
+
+	if useCache && cacheable(result) {
+		resultCache.Add(key, result)
+	}
+
+	return searchResult{PathfindingResult: result}
+}
+
+func pathOptions(req api.PathRequest) pathfinding.PathfindingOptions {
+	options := cfg.PathfindingOptions()
+	for _, field := range []struct {
+		value  *bool
+		option *bool
+	}{
+		{req.AllowBreaking, &options.AllowBreaking},
+		{req.AllowPlacing, &options.AllowPlacing},
+		{req.AvoidWater, &options.AvoidWater},
+		{req.MinVertical, &options.MinimiseHeight},
+	} {
+		if field.value != nil {
+			*field.option = *field.value
+		}
+	}
+	if req.MaxIterations > 0 {
+		options.MaxIterations = req.MaxIterations
+	}
+	options.MaxNodes = budgetOrLimit(req.MaxNodes, options.MaxNodes)
+	options.MaxFrontier = budgetOrLimit(req.MaxFrontier, options.MaxFrontier)
+	return options
+}
+
+func algorithmFor(name string) pathfinding.Algorithm {
+	if algorithm, ok := pathfinding.LookupAlgorithm(name); ok {
+		return algorithm
+	}
+
+	algorithm, _ := pathfinding.LookupAlgorithm("astar")
+	return algorithm
+}
+
+func newPathResponse(result searchResult, gameWorld *world.World) api.PathResponse {
+	return api.PathResponse{
 		Path:            result.Path,
 		ComputationTime: result.ComputationTime.Milliseconds(),
 		NodesExplored:   result.NodesExplored,
@@ -118,116 +291,268 @@ func findPathHandler(w http.ResponseWriter, r *http.Request) {
 		BlocksPlaced:    result.BlocksPlaced,
 		WaterCrossed:    result.WaterCrossed,
 		VerticalChange:  result.VerticalChange,
-		EstimatedTime:   estimateTimeToTraverse(result),
+		EstimatedTime:   estimateTimeToTraverse(result.PathfindingResult),
 		TotalCost:       result.TotalCost,
+		Actions:         newActions(planner.Plan(result.PathfindingResult, gameWorld)),
+		Cached:          result.cached,
+	}
+}
+
+func newActions(plan []planner.Action) []api.Action {
+	actions := make([]api.Action, len(plan))
+	for i, a := range plan {
+		actions[i] = api.Action{
+			Type:     string(a.Type),
+			From:     a.From,
+			To:       a.To,
+			Block:    a.Block,
+			Face:     a.Face,
+			Duration: a.Duration.Milliseconds(),
+		}
+	}
+	return actions
+}
+
+func buildWorld(req api.PathRequest) (*world.World, func(), error) {
+	start := pathfinding.Point{X: req.StartX, Y: req.StartY, Z: req.StartZ}
+	goal := pathfinding.Point{X: req.EndX, Y: req.EndY, Z: req.EndZ}
+
+	if req.World == nil {
+		gameWorld := world.NewWorld()
+		setupWorld(gameWorld, req)
+
+		gameWorld.SetBlock(start, world.MustBlock("air"))
+		gameWorld.SetBlock(goal, world.MustBlock("air"))
+
+		return gameWorld, func() {}, nil
 	}
 
-	if len(result.Path) == 0 {
-		response.Error = "No path found"
-		fmt.Println("No path found")
+	var gameWorld *world.World
+	release := func() {}
+
+	if req.World.ID != "" {
+		if countWorldSources(*req.World) > 0 {
+			return nil, nil, fmt.Errorf("world id cannot be combined with seed, scenario or blocks/regions")
+		}
+
+		sess, err := worldStore.Get(req.World.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		gameWorld, release = sess.Acquire()
 	} else {
-		fmt.Printf("Path found with %d steps\n", len(result.Path))
-		printPathStats(result)
+		built, err := worldFromSpec(*req.World, start, goal)
+		if err != nil {
+			return nil, nil, err
+		}
+		gameWorld = built
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		fmt.Printf("Error encoding response: %v\n", err)
+	if !gameWorld.InBounds(start) {
+		release()
+		return nil, nil, outOfBoundsError(api.CodeStartOutOfBounds, "start", start, gameWorld)
 	}
+	if !gameWorld.InBounds(goal) {
+		release()
+		return nil, nil, outOfBoundsError(api.CodeGoalOutOfBounds, "goal", goal, gameWorld)
+	}
+
+	return gameWorld, release, nil
 }
 
-func compareAlgorithmsHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+func countWorldSources(spec api.WorldSpec) int {
+	sources := 0
+	if spec.Seed != nil {
+		sources++
+	}
+	if spec.Scenario != "" {
+		sources++
+	}
+	if len(spec.Blocks) > 0 || len(spec.Regions) > 0 {
+		sources++
+	}
+	return sources
+}
+
+func worldFromSpec(spec api.WorldSpec, include ...pathfinding.Point) (*world.World, error) {
+	if countWorldSources(spec) > 1 {
+		return nil, fmt.Errorf("world must be given by exactly one of seed, scenario or blocks/regions")
+	}
+
+	switch {
+	case spec.Seed != nil:
+		return world.Generate(world.DefaultGeneratorOptions(*spec.Seed)), nil
+	case spec.Scenario != "":
+		scenario, ok := scenarios.Get(spec.Scenario)
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q", spec.Scenario)
+		}
+		return scenario.World(), nil
+	}
+
+	if len(spec.Blocks)+len(spec.Regions) > cfg.MaxWorldBlocks {
+		return nil, fmt.Errorf("%w: %d entries exceeds the limit of %d", errWorldTooLarge,
+			len(spec.Blocks)+len(spec.Regions), cfg.MaxWorldBlocks)
+	}
+
+	var minPoint, maxPoint pathfinding.Point
+
+	switch {
+	case spec.Min != nil && spec.Max != nil:
+		minPoint, maxPoint = *spec.Min, *spec.Max
+	case spec.Min != nil || spec.Max != nil:
+		return nil, fmt.Errorf("world min and max must be given together")
+	default:
+		var ok bool
+		minPoint, maxPoint, ok = world.BlockListBounds(spec.Blocks, spec.Regions, include...)
+		if !ok {
+			return nil, fmt.Errorf("world needs a seed, scenario, blocks, regions or min/max bounds")
+		}
+		maxPoint.Y++
+	}
+
+	for _, p := range []pathfinding.Point{minPoint, maxPoint} {
+		for _, v := range []int{p.X, p.Y, p.Z} {
+			if v < -maxCoordinate || v > maxCoordinate {
+				return nil, fmt.Errorf("world bounds must be between %d and %d, got %v", -maxCoordinate, maxCoordinate, p)
+			}
+		}
+	}
+
+	volume, ok := world.Volume(minPoint, maxPoint)
+	if !ok {
+		return nil, fmt.Errorf("%w: bounds %v to %v overflow", errWorldTooLarge, minPoint, maxPoint)
+	}
+	if volume > cfg.MaxWorldVolume {
+		return nil, fmt.Errorf("%w: volume %d exceeds the limit of %d", errWorldTooLarge, volume, cfg.MaxWorldVolume)
+	}
+
+	return world.FromBlocks(minPoint, maxPoint, spec.Regions, spec.Blocks)
+}
+
+func describeWorldSpec(spec *api.WorldSpec) string {
+	switch {
+	case spec == nil:
+		return "default"
+	case spec.ID != "":
+		return "stored " + spec.ID
+	case spec.Seed != nil:
+		return fmt.Sprintf("seed %d", *spec.Seed)
+	case spec.Scenario != "":
+		return "scenario " + spec.Scenario
+	case len(spec.Blocks) == 0 && len(spec.Regions) == 0:
+		return "empty"
+	default:
+		return fmt.Sprintf("%d blocks, %d regions", len(spec.Blocks), len(spec.Regions))
+	}
+}
+
+func describeRequest(req api.PathRequest) string {
+	return fmt.Sprintf("%s from (%d,%d,%d) to (%d,%d,%d) [world: %s]", req.Algorithm,
+		req.StartX, req.StartY, req.StartZ, req.EndX, req.EndY, req.EndZ, describeWorldSpec(req.World))
+}
+
+func generateWorldHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, errMethodNotAllowed)
 		return
 	}
 
-	var req PathRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	options, err := generatorOptionsFromQuery(r)
+	if err != nil {
+		writeError(w, invalidRequest(err))
 		return
 	}
 
-	fmt.Printf("Received algorithm comparison request for %+v\n", req)
+	loggerFrom(r.Context()).Debug("generating world", "seed", options.Seed)
 
-	gameWorld := world.NewWorld()
-	setupWorld(gameWorld, req)
+	gameWorld := world.Generate(options)
+	minPoint, maxPoint := gameWorld.Bounds()
 
-	start := pathfinding.Point{X: req.StartX, Y: req.StartY, Z: req.StartZ}
-	goal := pathfinding.Point{X: req.EndX, Y: req.EndY, Z: req.EndZ}
+	response := api.WorldResponse{
+		Seed:   options.Seed,
+		Min:    minPoint,
+		Max:    maxPoint,
+		Blocks: gameWorld.BlockList(),
+	}
 
-	gameWorld.SetBlock(start, world.Block{
-		Type:      "air",
-		Walkable:  true,
-		Breakable: false,
-		MoveCost:  1.0,
-	})
+	if start, goal, ok := world.SpawnPoints(gameWorld); ok {
+		response.Start = &start
+		response.Goal = &goal
+	}
 
-	gameWorld.SetBlock(goal, world.Block{
-		Type:      "air",
-		Walkable:  true,
-		Breakable: false,
-		MoveCost:  1.0,
-	})
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		loggerFrom(r.Context()).Warn("encoding response", "error", err)
+	}
+}
+
+func generatorOptionsFromQuery(r *http.Request) (world.GeneratorOptions, error) {
+	query := r.URL.Query()
 
-	options := pathfinding.PathfindingOptions{
-		AllowBreaking:  req.AllowBreaking,
-		AllowPlacing:   req.AllowPlacing,
-		AvoidWater:     req.AvoidWater,
-		MinimiseHeight: req.MinVertical,
+	seed := time.Now().UnixNano()
+	if raw := query.Get("seed"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return world.GeneratorOptions{}, fmt.Errorf("invalid seed %q", raw)
+		}
+		seed = parsed
+	}
+
+	options := world.DefaultGeneratorOptions(seed)
+
+	ints := []struct {
+		name  string
+		value *int
+		limit int
+	}{
+		{"sizeX", &options.SizeX, maxGeneratedSize},
+		{"sizeZ", &options.SizeZ, maxGeneratedSize},
+		{"height", &options.Height, maxGeneratedHeight},
+		{"seaLevel", &options.SeaLevel, maxGeneratedHeight},
 	}
 
-	astarResult := pathfinding.FindPathWithOptions(start, goal, gameWorld, options)
-	dijkstraResult := pathfinding.FindPathDijkstraWithOptions(start, goal, gameWorld, options)
-	bfsResult := pathfinding.FindPathBFSWithOptions(start, goal, gameWorld, options)
+	for _, param := range ints {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
 
-	type AlgorithmComparison struct {
-		Algorithm       string                `json:"algorithm"`
-		Path            []pathfinding.Point   `json:"path"`
-		ComputationTime int64                 `json:"computationTime"`
-		NodesExplored   int                   `json:"nodesExplored"`
-		PathLength      int                   `json:"pathLength"`
-		TotalCost       float64               `json:"totalCost"`
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > param.limit {
+			return world.GeneratorOptions{}, fmt.Errorf("%s must be an integer between 1 and %d", param.name, param.limit)
+		}
+		*param.value = parsed
 	}
 
-	response := struct {
-		AStar    AlgorithmComparison `json:"astar"`
-		Dijkstra AlgorithmComparison `json:"dijkstra"`
-		BFS      AlgorithmComparison `json:"bfs"`
+	bools := []struct {
+		name  string
+		value *bool
 	}{
-		AStar: AlgorithmComparison{
-			Algorithm:       "astar",
-			Path:            astarResult.Path,
-			ComputationTime: astarResult.ComputationTime.Milliseconds(),
-			NodesExplored:   astarResult.NodesExplored,
-			PathLength:      len(astarResult.Path),
-			TotalCost:       astarResult.TotalCost,
-		},
-		Dijkstra: AlgorithmComparison{
-			Algorithm:       "dijkstra",
-			Path:            dijkstraResult.Path,
-			ComputationTime: dijkstraResult.ComputationTime.Milliseconds(),
-			NodesExplored:   dijkstraResult.NodesExplored,
-			PathLength:      len(dijkstraResult.Path),
-			TotalCost:       dijkstraResult.TotalCost,
-		},
-		BFS: AlgorithmComparison{
-			Algorithm:       "bfs",
-			Path:            bfsResult.Path,
-			ComputationTime: bfsResult.ComputationTime.Milliseconds(),
-			NodesExplored:   bfsResult.NodesExplored,
-			PathLength:      len(bfsResult.Path),
-			TotalCost:       bfsResult.TotalCost,
-		},
+		{"caves", &options.Caves},
+		{"rivers", &options.Rivers},
+		{"ores", &options.Ores},
+		{"trees", &options.Trees},
+		{"lava", &options.Lava},
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		fmt.Printf("Error encoding response: %v\n", err)
+	for _, param := range bools {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return world.GeneratorOptions{}, fmt.Errorf("%s must be true or false", param.name)
+		}
+		*param.value = parsed
 	}
+
+	return options, nil
 }
 
-func setupWorld(gameWorld *world.World, req PathRequest) {
+func setupWorld(gameWorld *world.World, req api.PathRequest) {
 	minX, maxX := -20, 20
 	minY, maxY := 0, 10
 	minZ, maxZ := -20, 20
@@ -395,16 +720,17 @@ func estimateTimeToTraverse(result pathfinding.PathfindingResult) float64 {
 	return float64(len(result.Path)) * timePerBlock + breakingTime + placingTime + waterTime + verticalTime
 }
 
-func printPathStats(result pathfinding.PathfindingResult) {
-	fmt.Printf("Path length: %d blocks\n", len(result.Path))
-	fmt.Printf("Computation time: %v\n", result.ComputationTime)
-	fmt.Printf("Nodes explored: %d\n", result.NodesExplored)
-	fmt.Printf("Blocks broken: %d\n", len(result.BlocksBroken))
-	fmt.Printf("Blocks placed: %d\n", len(result.BlocksPlaced))
-	fmt.Printf("Water blocks crossed: %d\n", result.WaterCrossed)
-	fmt.Printf("Vertical change: %d blocks\n", result.VerticalChange)
-	fmt.Printf("Estimated traversal time: %.2f seconds\n", estimateTimeToTraverse(result))
-	fmt.Printf("Total path cost: %.2f\n", result.TotalCost)
+func logPathStats(ctx context.Context, result pathfinding.PathfindingResult) {
+	loggerFrom(ctx).Debug("path stats",
+		"pathLength", len(result.Path),
+		"computationTime", result.ComputationTime,
+		"nodesExplored", result.NodesExplored,
+		"blocksBroken", len(result.BlocksBroken),
+		"blocksPlaced", len(result.BlocksPlaced),
+		"waterCrossed", result.WaterCrossed,
+		"verticalChange", result.VerticalChange,
+		"estimatedTime", estimateTimeToTraverse(result),
+		"totalCost", result.TotalCost)
 }
 
 func max(a, b int) int {
@@ -414,38 +740,115 @@ func max(a, b int) int {
 	return b
 }
 
+func frontendFiles() (fs.FS, bool, error) {
+	if cfg.FrontendDir == "" {
+		return frontend.Files, false, nil
+	}
+
+	if info, err := os.Stat(cfg.FrontendDir); err != nil || !info.IsDir() {
+		return nil, false, fmt.Errorf("frontend directory %s does not exist", cfg.FrontendDir)
+	}
+	return os.DirFS(cfg.FrontendDir), true, nil
+}
+
+func fatal(msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+var commands = map[string]func(args []string) int{
+	"export": exportCommand,
+	"find":   findCommand,
+	"bench":  benchCommand,
+	"report": reportCommand,
+}
+
 func main() {
-	fmt.Println("Paritone Backend Starting...")
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			os.Exit(command(os.Args[2:]))
+		}
+	}
+
+	loaded, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fatal("invalid configuration", err)
+	}
+	cfg = loaded
+	searchSlots = make(chan struct{}, cfg.MaxConcurrentSearches)
+	clientLimiter = ratelimit.NewLimiter(cfg.RateLimit, cfg.RateBurst)
+	resultCache = resultcache.New(cfg.ResultCacheSize)
+
+	logger = newLogger(cfg, os.Stderr)
+	slog.SetDefault(logger)
+
+	logger.Info("paritone starting")
+	if cfg.LogFormat == "json" {
+		logger.Info("effective configuration", "config", cfg)
+	} else {
+		fmt.Fprint(os.Stderr, cfg.Describe())
+	}
 
-	http.HandleFunc("/api/find-path", enableCORS(findPathHandler))
-	http.HandleFunc("/api/compare-algorithms", enableCORS(compareAlgorithmsHandler))
+	handleAPI("/find-path", enableCORS(rateLimited(findPathHandler)))
+	handleAPI("/find-paths", enableCORS(rateLimited(findPathsHandler)))
+	handleAPI("/find-path/stream", enableCORS(rateLimited(findPathStreamHandler)))
+	handleAPI("/compare-algorithms", enableCORS(rateLimited(compareAlgorithmsHandler)))
+	handleAPI("/generate-world", enableCORS(rateLimited(generateWorldHandler)))
+	handleAPI("/scenarios", enableCORS(listScenariosHandler))
+	handleAPI("/scenarios/{id}", enableCORS(getScenarioHandler))
+	handleAPI("/worlds", enableCORS(rateLimited(worldsHandler)))
+	handleAPI("/worlds/{id}", enableCORS(rateLimited(worldHandler)))
+	handleAPI("/worlds/{id}/blocks", enableCORS(rateLimited(worldBlocksHandler)))
+	handleAPI("/jobs", enableCORS(rateLimited(jobsHandler)))
+	handleAPI("/jobs/{id}", enableCORS(jobHandler))
+	http.HandleFunc("GET "+api.Prefix+"/openapi.json", enableCORS(openAPIHandler))
+	http.HandleFunc("/metrics", metricsHandler)
+	http.HandleFunc("GET /healthz", healthHandler)
+	http.HandleFunc("GET /readyz", readyHandler)
+
+	worldStore.Janitor(time.Minute, func(ids []string) {
+		logger.Info("evicted idle worlds", "ids", ids)
+	})
+	jobManager.Janitor(time.Minute, func(ids []string) {
+		logger.Info("discarded expired jobs", "ids", ids)
+	})
+	clientLimiter.Janitor(time.Minute)
 
-	workDir, err := os.Getwd()
+	files, live, err := frontendFiles()
 	if err != nil {
-		log.Fatal("Failed to get working directory:", err)
+		fatal("invalid frontend directory", err)
 	}
 
-	frontendPath := filepath.Join(workDir, "frontend")
-	if _, err := os.Stat(frontendPath); os.IsNotExist(err) {
-		frontendPath = filepath.Join(workDir, "..", "..", "frontend")
-		if _, err := os.Stat(frontendPath); os.IsNotExist(err) {
-			log.Fatal("Frontend directory not found at:", frontendPath)
-		}
+	site, err := newStaticSite(files, live)
+	if err != nil {
+		fatal("loading frontend files", err)
+	}
+
+	if live {
+		logger.Info("serving frontend from disk", "dir", cfg.FrontendDir)
+	} else {
+		logger.Info("serving embedded frontend")
 	}
 
-	fmt.Println("Serving frontend files from:", frontendPath)
+	http.Handle("/", site)
 
-	fs := http.FileServer(http.Dir(frontendPath))
+	if err := loadOpenAPI(http.DefaultServeMux); err != nil {
+		fatal("checking the API description", err)
+	}
 
-	http.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/" {
-			http.ServeFile(w, r, filepath.Join(frontendPath, "index.html"))
-			return
-		}
-		
-		http.StripPrefix("/", fs).ServeHTTP(w, r)
-	}))
+	server := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           withRequestLogging(withBodyLimit(http.DefaultServeMux)),
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+		ReadTimeout:       cfg.ReadTimeout.Duration,
+		ReadHeaderTimeout: cfg.ReadTimeout.Duration,
+		WriteTimeout:      cfg.WriteTimeout.Duration,
+		IdleTimeout:       cfg.IdleTimeout.Duration,
+	}
 
-	fmt.Println("Server listening on http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
\ No newline at end of file
+	logger.Info("server listening", "addr", cfg.Listen)
+	serve(server)
+}
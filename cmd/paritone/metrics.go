@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/WillKirkmanM/paritone/internal/metrics"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+var (
+	metricsRegistry = metrics.NewRegistry()
+
+	searchesTotal = metricsRegistry.NewCounterVec("paritone_searches_total",
+		"Searches run, by algorithm and outcome (found or the error code).", "algorithm", "outcome")
+	searchDuration = metricsRegistry.NewHistogramVec("paritone_search_duration_seconds",
+		"Wall-clock time spent in a single search.", metrics.ExponentialBuckets(0.0005, 4, 10), "algorithm")
+	searchNodesExplored = metricsRegistry.NewHistogramVec("paritone_search_nodes_explored",
+		"Nodes expanded by a single search.", metrics.ExponentialBuckets(10, 4, 10), "algorithm")
+	searchWorldBlocks = metricsRegistry.NewHistogramVec("paritone_search_world_blocks",
+		"Blocks in the world a search ran against.", metrics.ExponentialBuckets(1000, 4, 8), "algorithm")
+	searchesInFlight = metricsRegistry.NewGauge("paritone_searches_in_flight",
+		"Searches currently running.")
+	cacheRequests = metricsRegistry.NewCounterVec("paritone_result_cache_requests_total",
+		"Result cache lookups, by result (hit or miss).", "result")
+	cacheInvalidations = metricsRegistry.NewCounterVec("paritone_result_cache_invalidations_total",
+		"Cached results dropped because their stored world changed.")
+
+	_ = metricsRegistry.NewGaugeFunc("paritone_search_slots",
+		"Maximum number of searches allowed to run at once.",
+		func() float64 { return float64(cap(searchSlots)) })
+	_ = metricsRegistry.NewGaugeFunc("paritone_result_cache_entries",
+		"Search results currently held in the result cache.",
+		func() float64 { return float64(resultCache.Len()) })
+	_ = metricsRegistry.NewGaugeFunc("paritone_worlds_stored",
+		"Editable worlds currently held in the world store.",
+		func() float64 { return float64(len(worldStore.List())) })
+	_ = metricsRegistry.NewGaugeFunc("go_goroutines",
+		"Number of goroutines that currently exist.",
+		func() float64 { return float64(runtime.NumGoroutine()) })
+	_ = metricsRegistry.NewGaugeFunc("go_memstats_heap_alloc_bytes",
+		"Number of heap bytes allocated and still in use.",
+		func() float64 {
+			var stats runtime.MemStats
+			runtime.ReadMemStats(&stats)
+			return float64(stats.HeapAlloc)
+		})
+)
+
+func recordSearch(algorithm pathfinding.Algorithm, gameWorld *world.World, result pathfinding.PathfindingResult) {
+	outcome := "found"
+	if result.Err != nil {
+		outcome = searchError(algorithm, result.Err).Code
+	}
+
+	searchesTotal.Inc(algorithm.Name, outcome)
+	searchDuration.Observe(result.ComputationTime.Seconds(), algorithm.Name)
+	searchNodesExplored.Observe(float64(result.NodesExplored), algorithm.Name)
+	searchWorldBlocks.Observe(float64(len(gameWorld.Blocks)), algorithm.Name)
+}
+
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	if err := metricsRegistry.Write(w); err != nil {
+		loggerFrom(r.Context()).Warn("writing metrics", "error", err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+var openAPIDocument []byte
+
+func handleAPI(path string, handler http.HandlerFunc) {
+	http.HandleFunc(api.Prefix+path, handler)
+	http.HandleFunc("/api"+path, handler)
+}
+
+func isVersioned(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, api.Prefix+"/")
+}
+
+func decodeJSON(r *http.Request, body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	if isVersioned(r) {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(openAPIDocument)
+}
+
+func loadOpenAPI(mux *http.ServeMux) error {
+	for _, route := range api.Routes {
+		path := strings.NewReplacer("{id}", "x").Replace(route.Path)
+		req, err := http.NewRequest(route.Method, path, nil)
+		if err != nil {
+			return err
+		}
+		if _, pattern := mux.Handler(req); pattern == "" || pattern == "/" {
+			return fmt.Errorf("%s %s is documented but not served", route.Method, route.Path)
+		}
+	}
+
+	document, err := json.MarshalIndent(api.OpenAPI(), "", "  ")
+	if err != nil {
+		return err
+	}
+	openAPIDocument = document
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/internal/bench"
+	"github.com/WillKirkmanM/paritone/internal/report"
+)
+
+func reportCommand(args []string) int {
+	fs := flag.NewFlagSet("paritone report", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: paritone report [flags] results.json\n\n")
+		fmt.Fprintf(fs.Output(), "Turns a paritone bench JSON report into a self-contained HTML page and a Markdown summary.\n")
+		fmt.Fprintf(fs.Output(), "Without -html or -md, Markdown without charts is written to standard output.\n\n")
+		fs.PrintDefaults()
+	}
+	var (
+		htmlOutput     = fs.String("html", "", "write an HTML report with inline SVG charts to this file")
+		markdownOutput = fs.String("md", "", "write a Markdown report to this file, with its charts as SVG files beside it")
+		title          = fs.String("title", "Paritone benchmark report", "report title")
+		comparisonFile = fs.String("comparison", "", "include a comparison written by paritone bench -comparison")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	var results bench.Report
+	var err error
+	if fs.Arg(0) == "-" {
+		results, err = bench.ReadJSON(os.Stdin)
+	} else {
+		results, err = readBenchReport(fs.Arg(0))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+		return 2
+	}
+
+	var comparison *bench.Comparison
+	if *comparisonFile != "" {
+		comparison, err = readComparison(*comparisonFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+			return 2
+		}
+	}
+
+	built := report.Build(*title, results, comparison)
+
+	if *htmlOutput != "" {
+		if err := writeOutput(*htmlOutput, func(w io.Writer) error { return report.WriteHTML(w, built) }); err != nil {
+			fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+			return 1
+		}
+	}
+
+	if *markdownOutput != "" {
+		images, err := writeChartFiles(*markdownOutput, built.AllCharts())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+			return 1
+		}
+		if err := writeOutput(*markdownOutput, func(w io.Writer) error { return report.WriteMarkdown(w, built, images) }); err != nil {
+			fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+			return 1
+		}
+	}
+
+	if *htmlOutput == "" && *markdownOutput == "" {
+		if err := report.WriteMarkdown(os.Stdout, built, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "paritone report: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func writeChartFiles(markdownFile string, charts []report.Chart) (map[string]string, error) {
+	dir := filepath.Dir(markdownFile)
+	base := strings.TrimSuffix(filepath.Base(markdownFile), filepath.Ext(markdownFile))
+
+	images := make(map[string]string, len(charts))
+	for _, chart := range charts {
+		name := fmt.Sprintf("%s-%s.svg", base, chart.Name)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(chart.SVG), 0o644); err != nil {
+			return nil, err
+		}
+		images[chart.Name] = name
+	}
+	return images, nil
+}
+
+func readComparison(name string) (*bench.Comparison, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var comparison bench.Comparison
+	if err := json.Unmarshal(data, &comparison); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &comparison, nil
+}
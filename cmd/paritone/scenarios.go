@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+func summariseScenario(s scenarios.Scenario) api.ScenarioSummary {
+	return api.ScenarioSummary{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		Start:       s.Start,
+		Goal:        s.Goal,
+	}
+}
+
+func listScenariosHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	all := scenarios.All()
+	response := make([]api.ScenarioSummary, len(all))
+	for i, s := range all {
+		response[i] = summariseScenario(s)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		loggerFrom(r.Context()).Warn("encoding response", "error", err)
+	}
+}
+
+func getScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	scenario, ok := scenarios.Get(id)
+	if !ok {
+		writeError(w, newAPIError(http.StatusNotFound, api.CodeNotFound, "Unknown scenario %q", id).With("scenario", id))
+		return
+	}
+
+	gameWorld := scenario.World()
+	minPoint, maxPoint := gameWorld.Bounds()
+
+	response := api.ScenarioResponse{
+		ScenarioSummary: summariseScenario(scenario),
+		Min:             minPoint,
+		Max:             maxPoint,
+		Blocks:          gameWorld.BlockList(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		loggerFrom(r.Context()).Warn("encoding response", "error", err)
+	}
+}
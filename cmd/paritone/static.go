@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+var staticContentTypes = map[string]string{
+	".html": "text/html; charset=utf-8",
+	".js":   "text/javascript; charset=utf-8",
+	".mjs":  "text/javascript; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".json": "application/json",
+	".svg":  "image/svg+xml",
+	".png":  "image/png",
+	".ico":  "image/x-icon",
+	".wasm": "application/wasm",
+}
+
+type staticSite struct {
+	files fs.FS
+	live  bool
+	etags map[string]string
+}
+
+func newStaticSite(files fs.FS, live bool) (*staticSite, error) {
+	site := &staticSite{files: files, live: live, etags: make(map[string]string)}
+
+	if live {
+		return site, nil
+	}
+
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(data)
+		site.etags[name] = `"` + hex.EncodeToString(sum[:8]) + `"`
+		return nil
+	})
+
+	return site, err
+}
+
+func (s *staticSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "index.html"
+	}
+
+	file, err := s.files.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, "Failed to read "+name, http.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+
+	ext := path.Ext(name)
+	contentType, known := staticContentTypes[ext]
+	if !known {
+		contentType = mime.TypeByExtension(ext)
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	modTime := info.ModTime()
+	if s.live {
+		w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		w.Header().Set("Cache-Control", "public, no-cache")
+		w.Header().Set("ETag", s.etags[name])
+		modTime = time.Time{}
+	}
+
+	http.ServeContent(w, r, name, modTime, content)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+type streamSettings struct {
+	interval      int
+	frontierLimit int
+	rate          time.Duration
+}
+
+const maxStreamFrontier = 2000
+
+func streamSettingsFromQuery(r *http.Request) (streamSettings, error) {
+	settings := streamSettings{
+		interval: 250,
+		rate:     50 * time.Millisecond,
+	}
+
+	query := r.URL.Query()
+
+	if raw := query.Get("interval"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return settings, fmt.Errorf("interval must be a positive integer")
+		}
+		settings.interval = parsed
+	}
+
+	if raw := query.Get("frontier"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > maxStreamFrontier {
+			return settings, fmt.Errorf("frontier must be an integer between 0 and %d", maxStreamFrontier)
+		}
+		settings.frontierLimit = parsed
+	}
+
+	if raw := query.Get("rate"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return settings, fmt.Errorf("rate must be a non-negative number of milliseconds")
+		}
+		settings.rate = time.Duration(parsed) * time.Millisecond
+	}
+
+	return settings, nil
+}
+
+func findPathStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, newAPIError(http.StatusInternalServerError, api.CodeInternal, "Streaming unsupported"))
+		return
+	}
+
+	settings, err := streamSettingsFromQuery(r)
+	if err != nil {
+		writeError(w, invalidRequest(err))
+		return
+	}
+
+	req := newPathRequest()
+	if err := decodeJSON(r, r.Body, &req); err != nil {
+		writeError(w, invalidRequest(err))
+		return
+	}
+
+	loggerFrom(r.Context()).Debug("streaming path request", "request", describeRequest(req))
+	annotate(w, slog.String("algorithm", req.Algorithm))
+
+	search, err := preparePathSearch(r.Context(), req, cfg.SearchTimeout.Duration, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer search.release()
+
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		loggerFrom(r.Context()).Warn("lifting write deadline for stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data interface{}) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			loggerFrom(r.Context()).Warn("encoding stream event", "event", event, "error", err)
+			return
+		}
+
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+		flusher.Flush()
+	}
+
+	send("start", api.StreamStartEvent{Algorithm: search.algorithm.Name, Start: search.start, Goal: search.goal})
+
+	var lastSent time.Time
+
+	search.options.ProgressInterval = settings.interval
+	search.options.ProgressFrontierLimit = settings.frontierLimit
+	search.options.Progress = func(progress pathfinding.SearchProgress) {
+		if time.Since(lastSent) < settings.rate {
+			return
+		}
+		lastSent = time.Now()
+
+		send("progress", api.StreamProgressEvent{
+			NodesExplored: progress.NodesExplored,
+			FrontierSize:  progress.FrontierSize,
+			BestFScore:    finiteOrNil(progress.BestFScore),
+			Bound:         finiteOrNil(progress.Bound),
+			Iteration:     progress.Iteration,
+			Current:       progress.Current,
+			Frontier:      progress.Frontier,
+			Elapsed:       progress.Elapsed.Milliseconds(),
+		})
+	}
+
+	result := search.run()
+
+	annotate(w, slog.Int("nodesExplored", result.NodesExplored))
+
+	if r.Context().Err() != nil {
+		annotate(w, slog.String("outcome", "client_cancelled"))
+		return
+	}
+
+	if result.Err != nil {
+		apiErr := searchError(search.algorithm, result.Err)
+		annotate(w, slog.String("outcome", apiErr.Code))
+		send("error", api.ErrorResponse{Error: apiErr})
+		return
+	}
+
+	annotate(w, slog.String("outcome", "found"), slog.Int("pathLength", len(result.Path)))
+
+	send("result", newPathResponse(result, search.world))
+}
+
+func finiteOrNil(v float64) *float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/session"
+	"github.com/WillKirkmanM/paritone/internal/world"
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+var worldStore = session.NewStore(30*time.Minute, 64)
+
+func worldsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, worldStore.List())
+	case "POST":
+		var req api.CreateWorldRequest
+		if err := decodeJSON(r, r.Body, &req); err != nil {
+			writeError(w, invalidRequest(err))
+			return
+		}
+
+		if req.World.ID != "" {
+			writeError(w, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest, "world id cannot be used as a source when creating a world"))
+			return
+		}
+
+		gameWorld, err := worldFromSpec(req.World)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		sess, err := worldStore.Create(req.ID, describeWorldSpec(&req.World), gameWorld)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		loggerFrom(r.Context()).Info("created world", "world", sess.ID, "source", sess.Source)
+		annotate(w, slog.String("world", sess.ID))
+
+		w.Header().Set("Location", "/api/worlds/"+sess.ID)
+		writeJSON(w, http.StatusCreated, sess.Info())
+	default:
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+func worldHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case "GET":
+		sess, err := worldStore.Get(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var response api.WorldDetailResponse
+		sess.Read(func(gameWorld *world.World) {
+			response.Blocks = gameWorld.BlockList()
+		})
+		response.WorldInfo = sess.Info()
+
+		writeJSON(w, http.StatusOK, response)
+	case "DELETE":
+		sess, err := worldStore.Get(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		hash := sess.Hash()
+		if !worldStore.Delete(id) {
+			writeError(w, fmt.Errorf("%w: %q", session.ErrNotFound, id))
+			return
+		}
+		invalidateResults(r, hash)
+
+		loggerFrom(r.Context()).Info("deleted world", "world", id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+func worldBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	sess, err := worldStore.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		var blocks []world.BlockData
+		sess.Read(func(gameWorld *world.World) {
+			blocks = gameWorld.BlockList()
+		})
+
+		writeJSON(w, http.StatusOK, blocks)
+	case "PUT":
+		var spec api.WorldSpec
+		if err := decodeJSON(r, r.Body, &spec); err != nil {
+			writeError(w, invalidRequest(err))
+			return
+		}
+
+		if spec.ID != "" {
+			writeError(w, newAPIError(http.StatusBadRequest, api.CodeInvalidRequest, "world id cannot be used as a source when replacing blocks"))
+			return
+		}
+
+		gameWorld, err := worldFromSpec(spec)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if previous := sess.Replace(gameWorld); previous.Hash() != gameWorld.Hash() {
+			invalidateResults(r, previous.Hash())
+		}
+		writeJSON(w, http.StatusOK, sess.Info())
+	case "PATCH":
+		var edit api.WorldEditRequest
+		if err := decodeJSON(r, r.Body, &edit); err != nil {
+			writeError(w, invalidRequest(err))
+			return
+		}
+
+		if len(edit.Blocks)+len(edit.Regions) > cfg.MaxWorldBlocks {
+			writeError(w, fmt.Errorf("%w: %d entries exceeds the limit of %d", errWorldTooLarge,
+				len(edit.Blocks)+len(edit.Regions), cfg.MaxWorldBlocks))
+			return
+		}
+
+		var before, after uint64
+		err := sess.Write(func(gameWorld *world.World) error {
+			before = gameWorld.Hash()
+			err := gameWorld.Apply(edit.Regions, edit.Blocks)
+			after = gameWorld.Hash()
+			return err
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if after != before {
+			invalidateResults(r, before)
+		}
+
+		writeJSON(w, http.StatusOK, sess.Info())
+	default:
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warn("encoding response", "error", err)
+	}
+}
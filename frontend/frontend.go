@@ -0,0 +1,6 @@
+package frontend
+
+import "embed"
+
+//go:embed *.html *.js
+var Files embed.FS
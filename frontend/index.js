@@ -82,6 +82,20 @@ const world = {
           opacity: 0.8,
         });
         break;
+      case "ladder":
+        material = new THREE.MeshLambertMaterial({
+          color: 0xa0522d,
+          transparent: true,
+          opacity: 0.6,
+        });
+        break;
+      case "door":
+        material = new THREE.MeshLambertMaterial({
+          color: 0x6d4c41,
+          transparent: true,
+          opacity: 0.75,
+        });
+        break;
       case "path":
         material = new THREE.MeshLambertMaterial({ color: 0xff0000 });
         break;
@@ -126,388 +140,64 @@ const world = {
   },
 };
 
-const worldScenarios = {
-  simple: {
-    name: "Simple Obstacles",
-    description: "Basic flat terrain with some stone obstacles",
-    start: { x: -15, y: 1, z: -15 },
-    goal: { x: 15, y: 1, z: 15 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
-
-      for (let x = -5; x <= 5; x++) {
-        for (let z = -5; z <= 5; z++) {
-          world.setBlock(x, 1, z, "stone");
-        }
-      }
-
-      for (let x = 10; x <= 15; x++) {
-        for (let z = 10; z <= 15; z++) {
-          world.setBlock(x, 1, z, "water");
-        }
-      }
-
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-  maze: {
-    name: "Complex Maze",
-    description: "Navigate through a complex maze with narrow passages",
-    start: { x: -18, y: 1, z: -18 },
-    goal: { x: 18, y: 1, z: 18 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
-
-      const mazePattern = [
-        "XXXXXXXXXXXXXXXXXXXXXXXXX",
-        "XS        X             X",
-        "XXXXXXXX  X  XXXXXXXXXXX",
-        "X         X  X           ",
-        "X  XXXXXXXX  X  XXXXXXXXX",
-        "X  X         X  X       X",
-        "X  X  XXXXXXXX  X  XXX  X",
-        "X  X  X         X  X X  X",
-        "X  X  X  XXXXXXXX  X X  X",
-        "X  X  X  X         X X  X",
-        "X  X  X  X  XXXXXXXX X  X",
-        "X  X  X  X  X        X  X",
-        "X  X  X  X  X  XXXXXX   X",
-        "X  X  X  X  X  X     XXXX",
-        "X  X  X  X  X  X  X     X",
-        "X  X  X  X  X  X  XXXX  X",
-        "X  X  X  X  X  X     X  X",
-        "X  X  X  X  X  XXXXX X  X",
-        "X  X  X  X  X        X  X",
-        "X  X  X  X  XXXXXXXXXX  X",
-        "X  X  X  X              X",
-        "X  X  X  XXXXXXXXXXXXXX X",
-        "X  X  X                 X",
-        "X  X  XXXXXXXXXXXXXXXXXXX",
-        "X  X                    G",
-        "XXXXXXXXXXXXXXXXXXXXXXXXXXE",
-      ];
-
-      for (let z = 0; z < mazePattern.length; z++) {
-        for (let x = 0; x < mazePattern[z].length; x++) {
-          const xPos = x - 12;
-          const zPos = z - 12;
-
-          if (mazePattern[z][x] === "X") {
-            world.setBlock(xPos, 1, zPos, "stone");
-          }
-        }
-      }
-
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-  multilevel: {
-    name: "Multi-Level Terrain",
-    description: "Navigate across different elevations",
-    start: { x: -18, y: 1, z: -18 },
-    goal: { x: 18, y: 5, z: 18 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
-
-      for (let x = -20; x < -5; x++) {
-        for (let z = -20; z < -5; z++) {
-          world.setBlock(x, 1, z, "air");
-        }
-      }
-
-      for (let x = -5; x <= 20; x++) {
-        for (let z = -20; z < -5; z++) {
-          world.setBlock(x, 1, z, "stone");
-          world.setBlock(x, 2, z, "air");
-        }
-      }
-
-      for (let x = -20; x < -5; x++) {
-        for (let z = -5; z <= 20; z++) {
-          world.setBlock(x, 1, z, "stone");
-          world.setBlock(x, 2, z, "stone");
-          world.setBlock(x, 3, z, "air");
-        }
-      }
-
-      for (let x = -5; x < 5; x++) {
-        for (let z = -5; z < 5; z++) {
-          world.setBlock(x, 1, z, "stone");
-          world.setBlock(x, 2, z, "stone");
-          world.setBlock(x, 3, z, "stone");
-          world.setBlock(x, 4, z, "air");
-        }
-      }
-
-      for (let x = 5; x <= 20; x++) {
-        for (let z = 5; z <= 20; z++) {
-          world.setBlock(x, 1, z, "stone");
-          world.setBlock(x, 2, z, "stone");
-          world.setBlock(x, 3, z, "stone");
-          world.setBlock(x, 4, z, "stone");
-          world.setBlock(x, 5, z, "air");
-        }
-      }
-
-      for (let i = 0; i < 5; i++) {
-        world.setBlock(-5 - i, 1, -15, "wood");
-      }
-
-      for (let i = 0; i < 5; i++) {
-        world.setBlock(-10, 2, -5 - i, "wood");
-      }
-
-      for (let i = 0; i < 5; i++) {
-        world.setBlock(-5 - i, 3, 0, "wood");
-      }
-
-      for (let i = 0; i < 5; i++) {
-        world.setBlock(0, 4, 5 + i, "wood");
-      }
-
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-  mixedMaterials: {
-    name: "Mixed Materials Challenge",
-    description:
-      "Navigate through different materials with varying traversal costs",
-    start: { x: -18, y: 1, z: 0 },
-    goal: { x: 18, y: 1, z: 0 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
-
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -5; z <= 5; z++) {
-          world.setBlock(x, 1, z, "air");
-        }
-      }
-
-      for (let x = -15; x < -5; x++) {
-        for (let z = -3; z <= 3; z++) {
-          world.setBlock(x, 1, z, "sand");
-        }
-      }
-
-      for (let x = -5; x < 5; x++) {
-        for (let z = -4; z <= 4; z++) {
-          world.setBlock(x, 1, z, "water");
-        }
-      }
-
-      for (let x = 5; x < 15; x++) {
-        for (let z = -3; z <= 3; z++) {
-          world.setBlock(x, 1, z, "ice");
-        }
-      }
-
-      for (let z = -5; z <= 5; z++) {
-        world.setBlock(-10, 1, z, "lava");
-        world.setBlock(10, 1, z, "lava");
-      }
-
-      for (let i = -2; i <= 2; i++) {
-        world.setBlock(-10, 1, i, "wood");
-        world.setBlock(10, 1, i, "wood");
-      }
-
-      for (let x = -5; x <= 5; x++) {
-        if (x % 2 === 0) {
-          world.setBlock(x, 1, -6, "stone");
-          world.setBlock(x, 1, 6, "stone");
-        }
-      }
-
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-  islands: {
-    name: "Islands Challenge",
-    description: "Find your way across disconnected islands",
-    start: { x: -18, y: 1, z: -18 },
-    goal: { x: 18, y: 1, z: 18 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "water");
-        }
-      }
+const worldScenarios = {};
 
-      const islands = [
-        { x: -18, z: -18, radius: 3 },
-        { x: -10, z: -12, radius: 2 },
-        { x: -5, z: -5, radius: 2 },
-        { x: 0, z: 0, radius: 3 },
-        { x: 8, z: 5, radius: 2 },
-        { x: 12, z: 12, radius: 2 },
-        { x: 18, z: 18, radius: 3 },
-      ];
-
-      for (const island of islands) {
-        for (let x = -island.radius; x <= island.radius; x++) {
-          for (let z = -island.radius; z <= island.radius; z++) {
-            if (x * x + z * z <= island.radius * island.radius) {
-              world.setBlock(island.x + x, 0, island.z + z, "grass");
-              world.setBlock(island.x + x, 1, island.z + z, "air");
-            }
-          }
-        }
-      }
-
-      const bridges = [
-        { x1: -15, z1: -15, x2: -10, z2: -12 },
-        { x1: -8, z1: -10, x2: -5, z2: -5 },
-        { x1: -3, z1: -3, x2: 0, z2: 0 },
-        { x1: 3, z1: 3, x2: 8, z2: 5 },
-        { x1: 10, z1: 7, x2: 12, z2: 12 },
-        { x1: 14, z1: 14, x2: 18, z2: 18 },
-      ];
-
-      for (const bridge of bridges) {
-        const dx = bridge.x2 - bridge.x1;
-        const dz = bridge.z2 - bridge.z1;
-        const steps = Math.max(Math.abs(dx), Math.abs(dz));
-
-        for (let i = 0; i <= steps; i++) {
-          const x = Math.floor(bridge.x1 + (dx * i) / steps);
-          const z = Math.floor(bridge.z1 + (dz * i) / steps);
-          world.setBlock(x, 0, z, "wood");
-          world.setBlock(x, 1, z, "air");
-        }
-      }
-
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-  algorithmComparison: {
-    name: "Algorithm Comparison",
-    description:
-      "Scenario designed to showcase the differences between pathfinding algorithms",
-    start: { x: -18, y: 1, z: 0 },
-    goal: { x: 18, y: 1, z: 0 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
+const generatedScenario = {
+  name: "Random Terrain",
+  description:
+    "Procedurally generated terrain with caves, rivers, ores, trees and lava, reproducible by seed",
+  start: { x: -18, y: 1, z: -18 },
+  goal: { x: 18, y: 1, z: 18 },
+  create: async function () {
+    const seedInput = document.getElementById("terrainSeed");
+    const seed = seedInput.value || Math.floor(Math.random() * 1000000);
 
-      for (let x = -18; x <= 18; x++) {
-        for (let z = -5; z <= 5; z++) {
-          world.setBlock(x, 1, z, "air");
-        }
-      }
+    const response = await fetch(`/api/generate-world?seed=${seed}`);
+    const data = await response.json();
 
-      for (let x = -15; x <= 15; x++) {
-        world.setBlock(x, 1, 0, "water");
-      }
+    seedInput.value = data.seed;
 
-      for (let x = -15; x <= 15; x++) {
-        world.setBlock(x, 1, 3, "ice");
-        world.setBlock(x, 1, -3, "ice");
-      }
-
-      for (let z = -3; z <= 3; z++) {
-        world.setBlock(-15, 1, z, "ice");
-        world.setBlock(15, 1, z, "ice");
-      }
+    for (const block of data.blocks) {
+      world.setBlock(block.x, block.y, block.z, block.type);
+    }
 
-      for (let i = -10; i <= 10; i += 5) {
-        world.setBlock(i, 1, 0, "stone");
-      }
+    if (data.start && data.goal) {
+      this.start = data.start;
+      this.goal = data.goal;
+    }
 
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
+    world.setBlock(this.start.x, this.start.y, this.start.z, "start");
+    world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
   },
-  algorithmShowcase: {
-    name: "Algorithm Showcase",
-    description:
-      "Complex scenario to showcase differences between pathfinding algorithms",
-    start: { x: -18, y: 1, z: 0 },
-    goal: { x: 18, y: 1, z: 0 },
-    create: function () {
-      for (let x = -20; x <= 20; x++) {
-        for (let z = -20; z <= 20; z++) {
-          world.setBlock(x, 0, z, "grass");
-        }
-      }
-
-      for (let x = -18; x <= 18; x++) {
-        for (let z = -10; z <= 10; z++) {
-          world.setBlock(x, 1, z, "air");
-        }
-      }
-
-      for (let x = -15; x <= 15; x++) {
-        world.setBlock(x, 1, 0, "water");
-      }
-
-      for (let x = -15; x <= 15; x++) {
-        world.setBlock(x, 1, 5, "ice");
-        world.setBlock(x, 1, -5, "ice");
-      }
-
-      for (let z = -5; z <= 5; z++) {
-        world.setBlock(-15, 1, z, "ice");
-        world.setBlock(15, 1, z, "ice");
-      }
-
-      for (let i = -10; i <= 10; i += 5) {
-        world.setBlock(i, 1, 0, "stone");
-      }
-
-      for (let x = -12; x <= 12; x += 3) {
-        for (let z = 2; z <= 4; z++) {
-          world.setBlock(x, 1, z, "stone");
-        }
-        for (let z = -4; z <= -2; z++) {
-          world.setBlock(x + 1, 1, z, "stone");
-        }
-      }
+};
 
-      for (let x = -8; x <= 8; x++) {
-        if (x % 4 != 0) {
-          world.setBlock(x, 1, 2, "stone");
-          world.setBlock(x, 1, -2, "stone");
+async function loadScenarioCatalogue() {
+  const response = await fetch("/api/scenarios");
+  const catalogue = await response.json();
+
+  for (const summary of catalogue) {
+    worldScenarios[summary.id] = {
+      name: summary.name,
+      description: summary.description,
+      start: summary.start,
+      goal: summary.goal,
+      serverScenario: true,
+      create: async function () {
+        const response = await fetch(`/api/scenarios/${summary.id}`);
+        const data = await response.json();
+
+        for (const block of data.blocks) {
+          world.setBlock(block.x, block.y, block.z, block.type);
         }
-      }
 
-      for (let x = -5; x <= 5; x++) {
-        for (let z = -1; z <= 1; z++) {
-          world.setBlock(x, 2, z, "wood");
-          if (x % 2 == 0 && z == 0) {
-            world.setBlock(x, 3, z, "wood");
-          }
-        }
-      }
+        world.setBlock(this.start.x, this.start.y, this.start.z, "start");
+        world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
+      },
+    };
+  }
 
-      world.setBlock(this.start.x, this.start.y, this.start.z, "start");
-      world.setBlock(this.goal.x, this.goal.y, this.goal.z, "goal");
-    },
-  },
-};
+  worldScenarios.generated = generatedScenario;
+}
 
 const ambientLight = new THREE.AmbientLight(0xffffff, 0.5);
 scene.add(ambientLight);
@@ -652,6 +342,7 @@ async function findPath(startX, startY, startZ, endX, endY, endZ) {
         heuristicWeight: heuristicWeight,
         maxIterations: maxIterations,
         jumpPointOptimisation: jumpPointOptimisation,
+        world: serialiseWorld(),
       }),
     });
 
@@ -660,7 +351,10 @@ async function findPath(startX, startY, startZ, endX, endY, endZ) {
 
     if (data.error) {
       console.error("Pathfinding error:", data.error);
-      document.getElementById("pathInfo").textContent = `Error: ${data.error}`;
+      document.getElementById("pathInfo").textContent =
+        data.error.code === "no_path"
+          ? `No path found (${data.error.details?.nodesExplored ?? 0} nodes explored)`
+          : `Error: ${data.error.message}`;
       return null;
     }
 
@@ -767,6 +461,31 @@ async function findPath(startX, startY, startZ, endX, endY, endZ) {
   }
 }
 
+const overlayBlockTypes = ["air", "start", "goal", "path", "break", "place"];
+
+function serialiseWorld() {
+  const scenarioKey = document.getElementById("scenarioSelector").value;
+  const seed = parseInt(document.getElementById("terrainSeed").value);
+
+  if (scenarioKey === "generated" && !isNaN(seed)) {
+    return { seed: seed };
+  }
+
+  if (worldScenarios[scenarioKey]?.serverScenario) {
+    return { scenario: scenarioKey };
+  }
+
+  const blocks = [];
+  for (const [key, block] of world.blocks.entries()) {
+    if (overlayBlockTypes.includes(block.type)) continue;
+
+    const [x, y, z] = key.split(",").map(Number);
+    blocks.push({ x: x, y: y, z: z, type: block.type });
+  }
+
+  return { blocks: blocks };
+}
+
 function updateStats(data) {
   document.getElementById("stat-path-length").textContent = `Length: ${
     data.path ? data.path.length : 0
@@ -845,7 +564,9 @@ function clearPathVisualisation() {
   }
 }
 
-function createScenarioUI() {
+async function createScenarioUI() {
+  await loadScenarioCatalogue();
+
   const scenarioSelector = document.getElementById("scenarioSelector");
 
   while (scenarioSelector.firstChild) {
@@ -893,6 +614,8 @@ function loadScenario(scenarioKey) {
 
   document.getElementById("scenarioDescription").textContent =
     scenario.description;
+  document.getElementById("terrainSeedContainer").style.display =
+    scenarioKey === "generated" ? "block" : "none";
 
   world.clearWorld();
 
@@ -933,6 +656,12 @@ document.getElementById("algorithm").addEventListener("change", function () {
   } else {
     advancedOptions.style.display = "none";
   }
+
+  for (const id of ["allowBreaking", "allowPlacing"]) {
+    const checkbox = document.getElementById(id);
+    checkbox.disabled = algorithm === "jps";
+    if (checkbox.disabled) checkbox.checked = false;
+  }
 });
 
 document
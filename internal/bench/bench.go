@@ -0,0 +1,304 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+type Case struct {
+	Scenario string
+	Name     string
+	World    *world.World
+	Start    pathfinding.Point
+	Goal     pathfinding.Point
+}
+
+func ScenarioCases(ids []string) ([]Case, error) {
+	if len(ids) == 0 {
+		ids = scenarios.IDs()
+	}
+
+	cases := make([]Case, 0, len(ids))
+	for _, id := range ids {
+		scenario, ok := scenarios.Get(id)
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q", id)
+		}
+		cases = append(cases, Case{
+			Scenario: scenario.ID,
+			Name:     scenario.ID,
+			World:    scenario.World(),
+			Start:    scenario.Start,
+			Goal:     scenario.Goal,
+		})
+	}
+	return cases, nil
+}
+
+func RandomCases(seed int64, count int) ([]Case, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	w := world.Generate(world.DefaultGeneratorOptions(seed))
+	lo, hi := w.Bounds()
+	rng := rand.New(rand.NewSource(seed))
+	scenario := fmt.Sprintf("random-%d", seed)
+
+	point := func() (pathfinding.Point, bool) {
+		for attempt := 0; attempt < 1000; attempt++ {
+			x := lo.X + rng.Intn(hi.X-lo.X+1)
+			z := lo.Z + rng.Intn(hi.Z-lo.Z+1)
+			if p, ok := world.StandableAt(w, x, z); ok {
+				return p, true
+			}
+		}
+		return pathfinding.Point{}, false
+	}
+
+	cases := make([]Case, 0, count)
+	for i := 0; i < count; i++ {
+		start, ok := point()
+		if !ok {
+			return nil, fmt.Errorf("seed %d has no standable points", seed)
+		}
+		goal, ok := point()
+		for ok && goal == start {
+			goal, ok = point()
+		}
+		if !ok {
+			return nil, fmt.Errorf("seed %d has no standable points", seed)
+		}
+
+		cases = append(cases, Case{
+			Scenario: scenario,
+			Name:     fmt.Sprintf("%s/%d", scenario, i+1),
+			World:    w,
+			Start:    start,
+			Goal:     goal,
+		})
+	}
+	return cases, nil
+}
+
+type Config struct {
+	Algorithms  []pathfinding.Algorithm
+	Options     pathfinding.PathfindingOptions
+	Warmup      int
+	Repetitions int
+	Timeout     time.Duration
+	OnResult    func(Result)
+}
+
+type Result struct {
+	Scenario        string            `json:"scenario"`
+	Case            string            `json:"case"`
+	Algorithm       string            `json:"algorithm"`
+	Label           string            `json:"label"`
+	Optimal         bool              `json:"optimal"`
+	Start           pathfinding.Point `json:"start"`
+	Goal            pathfinding.Point `json:"goal"`
+	Runs            int               `json:"runs"`
+	Found           bool              `json:"found"`
+	Error           string            `json:"error,omitempty"`
+	TimeMin         float64           `json:"timeMinMicros"`
+	TimeMean        float64           `json:"timeMeanMicros"`
+	TimeP50         float64           `json:"timeP50Micros"`
+	TimeP90         float64           `json:"timeP90Micros"`
+	TimeP99         float64           `json:"timeP99Micros"`
+	TimeMax         float64           `json:"timeMaxMicros"`
+	Samples         []float64         `json:"samplesMicros"`
+	NodesExplored   int               `json:"nodesExplored"`
+	MaxMemoryUsed   int               `json:"maxMemoryUsed"`
+	AllocsPerRun    uint64            `json:"allocsPerRun"`
+	BytesPerRun     uint64            `json:"bytesPerRun"`
+	PathLength      int               `json:"pathLength"`
+	TotalCost       float64           `json:"totalCost"`
+	OptimalityRatio *float64          `json:"optimalityRatio,omitempty"`
+}
+
+func (r Result) Key() string {
+	return r.Case + "|" + r.Algorithm
+}
+
+type Environment struct {
+	GoVersion string    `json:"goVersion"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	CPUs      int       `json:"cpus"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+type Report struct {
+	Environment Environment `json:"environment"`
+	Warmup      int         `json:"warmup"`
+	Repetitions int         `json:"repetitions"`
+	Results     []Result    `json:"results"`
+}
+
+func Run(ctx context.Context, cases []Case, config Config) (Report, error) {
+	if config.Repetitions <= 0 {
+		return Report{}, fmt.Errorf("repetitions must be positive")
+	}
+
+	report := Report{
+		Environment: Environment{
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			CPUs:      runtime.NumCPU(),
+			StartedAt: time.Now().UTC(),
+		},
+		Warmup:      config.Warmup,
+		Repetitions: config.Repetitions,
+	}
+
+	for _, c := range cases {
+		results := make([]Result, 0, len(config.Algorithms))
+		for _, algorithm := range config.Algorithms {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			results = append(results, measure(ctx, c, algorithm, config))
+		}
+
+		setOptimalityRatios(results)
+		for _, result := range results {
+			if config.OnResult != nil {
+				config.OnResult(result)
+			}
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	return report, nil
+}
+
+func measure(ctx context.Context, c Case, algorithm pathfinding.Algorithm, config Config) Result {
+	result := Result{
+		Scenario:  c.Scenario,
+		Case:      c.Name,
+		Algorithm: algorithm.Name,
+		Label:     algorithm.Label,
+		Optimal:   algorithm.Optimal,
+		Start:     c.Start,
+		Goal:      c.Goal,
+	}
+
+	search := func() pathfinding.PathfindingResult {
+		options := config.Options
+		searchCtx, cancel := ctx, context.CancelFunc(func() {})
+		if config.Timeout > 0 {
+			searchCtx, cancel = context.WithTimeout(ctx, config.Timeout)
+		}
+		defer cancel()
+		options.Context = searchCtx
+
+		return algorithm.Search(c.Start, c.Goal, c.World, options)
+	}
+
+	for i := 0; i < config.Warmup; i++ {
+		if found := search(); found.Err != nil {
+			result.Error = found.Err.Error()
+			return result
+		}
+	}
+
+	var allocs, bytes uint64
+	var before, after runtime.MemStats
+	var last pathfinding.PathfindingResult
+
+	for i := 0; i < config.Repetitions; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		last = search()
+		runtime.ReadMemStats(&after)
+
+		if last.Err != nil {
+			result.Error = last.Err.Error()
+			return result
+		}
+
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+		result.Samples = append(result.Samples, micros(last.ComputationTime))
+		result.Runs++
+	}
+
+	sorted := append([]float64(nil), result.Samples...)
+	sort.Float64s(sorted)
+
+	result.Found = true
+	result.TimeMin = sorted[0]
+	result.TimeMax = sorted[len(sorted)-1]
+	result.TimeMean = Mean(sorted)
+	result.TimeP50 = Percentile(sorted, 50)
+	result.TimeP90 = Percentile(sorted, 90)
+	result.TimeP99 = Percentile(sorted, 99)
+	result.NodesExplored = last.NodesExplored
+	result.MaxMemoryUsed = last.MaxMemoryUsed
+	result.AllocsPerRun = allocs / uint64(result.Runs)
+	result.BytesPerRun = bytes / uint64(result.Runs)
+	result.PathLength = len(last.Path)
+	result.TotalCost = last.TotalCost
+
+	return result
+}
+
+func setOptimalityRatios(results []Result) {
+	best := math.Inf(1)
+	for _, r := range results {
+		if r.Found && r.TotalCost < best {
+			best = r.TotalCost
+		}
+	}
+	if math.IsInf(best, 1) {
+		return
+	}
+
+	for i := range results {
+		if !results[i].Found {
+			continue
+		}
+		ratio := 1.0
+		if best > 0 {
+			ratio = results[i].TotalCost / best
+		}
+		results[i].OptimalityRatio = &ratio
+	}
+}
+
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func micros(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1000
+}
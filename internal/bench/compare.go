@@ -0,0 +1,268 @@
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+type Thresholds struct {
+	Time  float64 `json:"time"`
+	Nodes float64 `json:"nodes"`
+	Cost  float64 `json:"cost"`
+	Alpha float64 `json:"alpha"`
+}
+
+func DefaultThresholds() Thresholds {
+	return Thresholds{Time: 0.10, Nodes: 0.05, Cost: 0, Alpha: 0.05}
+}
+
+type Change struct {
+	Scenario    string   `json:"scenario"`
+	Case        string   `json:"case"`
+	Algorithm   string   `json:"algorithm"`
+	Metric      string   `json:"metric"`
+	Baseline    float64  `json:"baseline"`
+	Current     float64  `json:"current"`
+	Delta       float64  `json:"delta"`
+	PValue      *float64 `json:"pValue,omitempty"`
+	Significant bool     `json:"significant"`
+	Regression  bool     `json:"regression"`
+	Improvement bool     `json:"improvement"`
+	Detail      string   `json:"detail,omitempty"`
+}
+
+type Comparison struct {
+	Thresholds   Thresholds `json:"thresholds"`
+	Changes      []Change   `json:"changes"`
+	Compared     int        `json:"compared"`
+	Missing      []string   `json:"missing,omitempty"`
+	Untimed      []string   `json:"untimed,omitempty"`
+	Added        []string   `json:"added,omitempty"`
+	Regressions  int        `json:"regressions"`
+	Improvements int        `json:"improvements"`
+}
+
+func (c Comparison) Failed() bool {
+	return c.Regressions > 0 || len(c.Missing) > 0
+}
+
+func Compare(baseline, current Report, thresholds Thresholds) Comparison {
+	comparison := Comparison{Thresholds: thresholds}
+
+	previous := make(map[string]Result, len(baseline.Results))
+	for _, r := range baseline.Results {
+		previous[r.Key()] = r
+	}
+
+	seen := make(map[string]bool, len(current.Results))
+	for _, now := range current.Results {
+		key := now.Key()
+		seen[key] = true
+
+		before, ok := previous[key]
+		if !ok {
+			comparison.Added = append(comparison.Added, key)
+			continue
+		}
+		comparison.Compared++
+		if before.Found && now.Found && (len(before.Samples) < minSamples || len(now.Samples) < minSamples) {
+			comparison.Untimed = append(comparison.Untimed, key)
+		}
+
+		for _, change := range compareResult(before, now, thresholds) {
+			if change.Regression {
+				comparison.Regressions++
+			}
+			if change.Improvement {
+				comparison.Improvements++
+			}
+			comparison.Changes = append(comparison.Changes, change)
+		}
+	}
+
+	for _, r := range baseline.Results {
+		if !seen[r.Key()] {
+			comparison.Missing = append(comparison.Missing, r.Key())
+		}
+	}
+
+	sort.SliceStable(comparison.Changes, func(i, j int) bool {
+		return comparison.Changes[i].Regression && !comparison.Changes[j].Regression
+	})
+
+	return comparison
+}
+
+func compareResult(before, now Result, thresholds Thresholds) []Change {
+	change := func(metric string, baseline, current float64) Change {
+		return Change{
+			Scenario: now.Scenario, Case: now.Case, Algorithm: now.Algorithm,
+			Metric: metric, Baseline: baseline, Current: current,
+			Delta: relativeChange(baseline, current),
+		}
+	}
+
+	switch {
+	case before.Found && !now.Found:
+		c := change("found", 1, 0)
+		c.Significant, c.Regression, c.Detail = true, true, now.Error
+		return []Change{c}
+	case !before.Found && now.Found:
+		c := change("found", 0, 1)
+		c.Significant, c.Improvement = true, true
+		return []Change{c}
+	case !before.Found:
+		return nil
+	}
+
+	var changes []Change
+
+	timeChange := change("time", before.TimeP50, now.TimeP50)
+	if p, ok := MannWhitneyU(before.Samples, now.Samples); ok {
+		timeChange.PValue = &p
+		timeChange.Significant = p < thresholds.Alpha
+	}
+	classify(&timeChange, thresholds.Time)
+	if timeChange.Significant {
+		changes = append(changes, timeChange)
+	}
+
+	for _, c := range []struct {
+		metric    string
+		before    float64
+		now       float64
+		threshold float64
+	}{
+		{"nodes", float64(before.NodesExplored), float64(now.NodesExplored), thresholds.Nodes},
+		{"cost", before.TotalCost, now.TotalCost, thresholds.Cost},
+	} {
+		if c.before == c.now {
+			continue
+		}
+		deterministic := change(c.metric, c.before, c.now)
+		deterministic.Significant = true
+		classify(&deterministic, c.threshold)
+		changes = append(changes, deterministic)
+	}
+
+	return changes
+}
+
+func classify(c *Change, threshold float64) {
+	if !c.Significant {
+		return
+	}
+	c.Regression = c.Delta > threshold+1e-9
+	c.Improvement = c.Delta < -threshold-1e-9
+}
+
+func relativeChange(baseline, current float64) float64 {
+	if baseline == 0 {
+		if current == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (current - baseline) / baseline
+}
+
+const minSamples = 4
+
+func MannWhitneyU(a, b []float64) (float64, bool) {
+	n1, n2 := len(a), len(b)
+	if n1 < minSamples || n2 < minSamples {
+		return 0, false
+	}
+
+	type sample struct {
+		value float64
+		first bool
+	}
+	combined := make([]sample, 0, n1+n2)
+	for _, v := range a {
+		combined = append(combined, sample{v, true})
+	}
+	for _, v := range b {
+		combined = append(combined, sample{v, false})
+	}
+	sort.Slice(combined, func(i, j int) bool { return combined[i].value < combined[j].value })
+
+	n := float64(n1 + n2)
+	rankSum, ties := 0.0, 0.0
+	for i := 0; i < len(combined); {
+		j := i
+		for j < len(combined) && combined[j].value == combined[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if combined[k].first {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	u := rankSum - float64(n1*(n1+1))/2
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1, true
+	}
+
+	z := math.Max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2), true
+}
+
+func WriteComparison(w io.Writer, c Comparison) error {
+	out := bufio.NewWriter(w)
+
+	for _, change := range c.Changes {
+		verdict := "changed"
+		switch {
+		case change.Regression:
+			verdict = "REGRESSION"
+		case change.Improvement:
+			verdict = "improved"
+		}
+
+		fmt.Fprintf(out, "%-10s %-24s %-14s %s\n", verdict, change.Case, change.Algorithm, describeChange(change))
+	}
+
+	for _, key := range c.Missing {
+		fmt.Fprintf(out, "%-10s %s\n", "missing", key)
+	}
+	for _, key := range c.Added {
+		fmt.Fprintf(out, "%-10s %s\n", "new", key)
+	}
+	if len(c.Untimed) > 0 {
+		fmt.Fprintf(out, "warning: time not compared for %d results with fewer than %d samples on a side, such as %s; use -repetitions %d or more, ideally 10\n",
+			len(c.Untimed), minSamples, c.Untimed[0], minSamples)
+	}
+
+	fmt.Fprintf(out, "%d compared: %d regressions, %d improvements, %d missing, %d new (time > %+.0f%% at p < %g, nodes > %+.0f%%, cost > %+.0f%%)\n",
+		c.Compared, c.Regressions, c.Improvements, len(c.Missing), len(c.Added),
+		c.Thresholds.Time*100, c.Thresholds.Alpha, c.Thresholds.Nodes*100, c.Thresholds.Cost*100)
+
+	return out.Flush()
+}
+
+func describeChange(c Change) string {
+	switch c.Metric {
+	case "found":
+		if c.Current == 0 {
+			return "no longer finds a path: " + c.Detail
+		}
+		return "now finds a path"
+	case "time":
+		return fmt.Sprintf("time p50 %.1fµs -> %.1fµs (%+.1f%%, p=%.3g)", c.Baseline, c.Current, c.Delta*100, *c.PValue)
+	case "cost":
+		return fmt.Sprintf("cost %.2f -> %.2f (%+.1f%%)", c.Baseline, c.Current, c.Delta*100)
+	}
+	return fmt.Sprintf("%s %.0f -> %.0f (%+.1f%%)", c.Metric, c.Baseline, c.Current, c.Delta*100)
+}
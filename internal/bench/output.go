@@ -0,0 +1,67 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{
+	"scenario", "case", "algorithm", "optimal",
+	"start_x", "start_y", "start_z", "goal_x", "goal_y", "goal_z",
+	"runs", "found", "error",
+	"time_min_us", "time_mean_us", "time_p50_us", "time_p90_us", "time_p99_us", "time_max_us",
+	"nodes_explored", "max_memory_used", "allocs_per_run", "bytes_per_run",
+	"path_length", "total_cost", "optimality_ratio",
+}
+
+func WriteCSV(w io.Writer, report Report) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	float := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 3, 64)
+	}
+
+	for _, r := range report.Results {
+		ratio := ""
+		if r.OptimalityRatio != nil {
+			ratio = strconv.FormatFloat(*r.OptimalityRatio, 'f', 4, 64)
+		}
+
+		record := []string{
+			r.Scenario, r.Case, r.Algorithm, strconv.FormatBool(r.Optimal),
+			strconv.Itoa(r.Start.X), strconv.Itoa(r.Start.Y), strconv.Itoa(r.Start.Z),
+			strconv.Itoa(r.Goal.X), strconv.Itoa(r.Goal.Y), strconv.Itoa(r.Goal.Z),
+			strconv.Itoa(r.Runs), strconv.FormatBool(r.Found), r.Error,
+			float(r.TimeMin), float(r.TimeMean), float(r.TimeP50), float(r.TimeP90), float(r.TimeP99), float(r.TimeMax),
+			strconv.Itoa(r.NodesExplored), strconv.Itoa(r.MaxMemoryUsed),
+			strconv.FormatUint(r.AllocsPerRun, 10), strconv.FormatUint(r.BytesPerRun, 10),
+			strconv.Itoa(r.PathLength), float(r.TotalCost), ratio,
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func WriteJSON(w io.Writer, report Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func ReadJSON(r io.Reader) (Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return Report{}, fmt.Errorf("reading benchmark report: %w", err)
+	}
+	return report, nil
+}
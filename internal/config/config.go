@@ -0,0 +1,552 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+const envPrefix = "PARITONE_"
+
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		d.Duration = time.Duration(v * float64(time.Second))
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		d.Duration = parsed
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+
+	return nil
+}
+
+type SearchDefaults struct {
+	Algorithm        string `json:"algorithm"`
+	AllowBreaking    bool   `json:"allowBreaking"`
+	AllowPlacing     bool   `json:"allowPlacing"`
+	AvoidWater       bool   `json:"avoidWater"`
+	MinimiseVertical bool   `json:"minimiseVertical"`
+	MaxIterations    int    `json:"maxIterations"`
+}
+
+type Config struct {
+	Listen                string         `json:"listen"`
+	FrontendDir           string         `json:"frontendDir"`
+	AllowedOrigins        []string       `json:"allowedOrigins"`
+	ReadTimeout           Duration       `json:"readTimeout"`
+	WriteTimeout          Duration       `json:"writeTimeout"`
+	SearchTimeout         Duration       `json:"searchTimeout"`
+	IdleTimeout           Duration       `json:"idleTimeout"`
+	ShutdownDelay         Duration       `json:"shutdownDelay"`
+	ShutdownTimeout       Duration       `json:"shutdownTimeout"`
+	MaxWorldVolume        int            `json:"maxWorldVolume"`
+	MaxWorldBlocks        int            `json:"maxWorldBlocks"`
+	MaxConcurrentSearches int            `json:"maxConcurrentSearches"`
+	MaxBodyBytes          int            `json:"maxBodyBytes"`
+	MaxBatchQueries       int            `json:"maxBatchQueries"`
+	MaxSearchNodes        int            `json:"maxSearchNodes"`
+	MaxSearchFrontier     int            `json:"maxSearchFrontier"`
+	MaxSearchIterations   int            `json:"maxSearchIterations"`
+	ResultCacheSize       int            `json:"resultCacheSize"`
+	RateLimit             float64        `json:"rateLimit"`
+	RateBurst             int            `json:"rateBurst"`
+	LogLevel              string         `json:"logLevel"`
+	LogFormat             string         `json:"logFormat"`
+	Defaults              SearchDefaults `json:"defaults"`
+}
+
+func Default() Config {
+	return Config{
+		Listen:                ":8080",
+		AllowedOrigins:        []string{"*"},
+		ReadTimeout:           Duration{15 * time.Second},
+		WriteTimeout:          Duration{2 * time.Minute},
+		SearchTimeout:         Duration{time.Minute},
+		IdleTimeout:           Duration{time.Minute},
+		ShutdownDelay:         Duration{5 * time.Second},
+		ShutdownTimeout:       Duration{30 * time.Second},
+		MaxWorldVolume:        128 * 64 * 128,
+		MaxWorldBlocks:        256 * 1024,
+		MaxConcurrentSearches: 8,
+		MaxBodyBytes:          8 << 20,
+		MaxBatchQueries:       1000,
+		MaxSearchNodes:        2_000_000,
+		MaxSearchFrontier:     1_000_000,
+		MaxSearchIterations:   100_000,
+		ResultCacheSize:       1024,
+		RateLimit:             10,
+		RateBurst:             20,
+		LogLevel:              "info",
+		LogFormat:             "text",
+		Defaults: SearchDefaults{
+			Algorithm:     "astar",
+			MaxIterations: 1000,
+		},
+	}
+}
+
+func (c Config) PathfindingOptions() pathfinding.PathfindingOptions {
+	return pathfinding.PathfindingOptions{
+		AllowBreaking:  c.Defaults.AllowBreaking,
+		AllowPlacing:   c.Defaults.AllowPlacing,
+		AvoidWater:     c.Defaults.AvoidWater,
+		MinimiseHeight: c.Defaults.MinimiseVertical,
+		MaxIterations:  c.Defaults.MaxIterations,
+		MaxNodes:       c.MaxSearchNodes,
+		MaxFrontier:    c.MaxSearchFrontier,
+	}
+}
+
+func Load(args []string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("paritone", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	var (
+		configPath    = fs.String("config", "", "path to a JSON or YAML config file")
+		listen        = fs.String("listen", cfg.Listen, "address to listen on")
+		frontendDir   = fs.String("frontend", cfg.FrontendDir, "serve the frontend from this directory instead of the embedded copy")
+		origins       = fs.String("origins", strings.Join(cfg.AllowedOrigins, ","), "comma separated list of allowed CORS origins")
+		readTimeout   = fs.Duration("read-timeout", cfg.ReadTimeout.Duration, "maximum duration for reading a request")
+		writeTimeout  = fs.Duration("write-timeout", cfg.WriteTimeout.Duration, "maximum duration for writing a response")
+		searchTimeout = fs.Duration("search-timeout", cfg.SearchTimeout.Duration, "maximum duration of a single search")
+		idleTimeout   = fs.Duration("idle-timeout", cfg.IdleTimeout.Duration, "how long idle keep-alive connections stay open")
+		shutdownDelay = fs.Duration("shutdown-delay", cfg.ShutdownDelay.Duration, "how long /readyz reports shutting down before the listener closes")
+		shutdown      = fs.Duration("shutdown-timeout", cfg.ShutdownTimeout.Duration, "grace period for running searches when shutting down")
+		maxVolume     = fs.Int("max-world-volume", cfg.MaxWorldVolume, "maximum number of cells in a supplied world")
+		maxBlocks     = fs.Int("max-world-blocks", cfg.MaxWorldBlocks, "maximum number of blocks and regions in a supplied world")
+		maxSearches   = fs.Int("max-concurrent-searches", cfg.MaxConcurrentSearches, "maximum number of searches running at once")
+		maxBody       = fs.Int("max-body-bytes", cfg.MaxBodyBytes, "maximum size of a request body in bytes")
+		maxBatch      = fs.Int("max-batch-queries", cfg.MaxBatchQueries, "maximum number of queries in one batch request")
+		maxNodes      = fs.Int("max-search-nodes", cfg.MaxSearchNodes, "maximum nodes a single search may expand")
+		maxFrontier   = fs.Int("max-search-frontier", cfg.MaxSearchFrontier, "maximum frontier size a single search may hold")
+		maxIterCap    = fs.Int("max-search-iterations", cfg.MaxSearchIterations, "largest maxIterations a request may ask for")
+		cacheSize     = fs.Int("result-cache-size", cfg.ResultCacheSize, "number of search results to cache, 0 to disable")
+		rateLimit     = fs.Float64("rate-limit", cfg.RateLimit, "requests per second allowed from each client, 0 to disable")
+		rateBurst     = fs.Int("rate-burst", cfg.RateBurst, "requests a client may make in a burst above the rate limit")
+		logLevel      = fs.String("log-level", cfg.LogLevel, "minimum log level: debug, info, warn or error")
+		logFormat     = fs.String("log-format", cfg.LogFormat, "log output format: text or json")
+		algorithm     = fs.String("default-algorithm", cfg.Defaults.Algorithm, "algorithm used when a request does not name one")
+		breaking      = fs.Bool("default-allow-breaking", cfg.Defaults.AllowBreaking, "allow breaking blocks unless a request says otherwise")
+		placing       = fs.Bool("default-allow-placing", cfg.Defaults.AllowPlacing, "allow placing blocks unless a request says otherwise")
+		avoidWater    = fs.Bool("default-avoid-water", cfg.Defaults.AvoidWater, "avoid water unless a request says otherwise")
+		minVertical   = fs.Bool("default-minimise-vertical", cfg.Defaults.MinimiseVertical, "minimise vertical movement unless a request says otherwise")
+		maxIterations = fs.Int("default-max-iterations", cfg.Defaults.MaxIterations, "iteration limit for iterative algorithms such as IDA*")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	path := *configPath
+	if path == "" {
+		path = getenv(envPrefix + "CONFIG")
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return cfg, err
+		}
+	}
+
+	if err := cfg.applyEnv(getenv); err != nil {
+		return cfg, err
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.Listen = *listen
+		case "frontend":
+			cfg.FrontendDir = *frontendDir
+		case "origins":
+			cfg.AllowedOrigins = splitList(*origins)
+		case "read-timeout":
+			cfg.ReadTimeout.Duration = *readTimeout
+		case "write-timeout":
+			cfg.WriteTimeout.Duration = *writeTimeout
+		case "search-timeout":
+			cfg.SearchTimeout.Duration = *searchTimeout
+		case "idle-timeout":
+			cfg.IdleTimeout.Duration = *idleTimeout
+		case "shutdown-delay":
+			cfg.ShutdownDelay.Duration = *shutdownDelay
+		case "shutdown-timeout":
+			cfg.ShutdownTimeout.Duration = *shutdown
+		case "max-world-volume":
+			cfg.MaxWorldVolume = *maxVolume
+		case "max-world-blocks":
+			cfg.MaxWorldBlocks = *maxBlocks
+		case "max-concurrent-searches":
+			cfg.MaxConcurrentSearches = *maxSearches
+		case "max-body-bytes":
+			cfg.MaxBodyBytes = *maxBody
+		case "max-batch-queries":
+			cfg.MaxBatchQueries = *maxBatch
+		case "max-search-nodes":
+			cfg.MaxSearchNodes = *maxNodes
+		case "max-search-frontier":
+			cfg.MaxSearchFrontier = *maxFrontier
+		case "max-search-iterations":
+			cfg.MaxSearchIterations = *maxIterCap
+		case "result-cache-size":
+			cfg.ResultCacheSize = *cacheSize
+		case "rate-limit":
+			cfg.RateLimit = *rateLimit
+		case "rate-burst":
+			cfg.RateBurst = *rateBurst
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		case "log-format":
+			cfg.LogFormat = *logFormat
+		case "default-algorithm":
+			cfg.Defaults.Algorithm = *algorithm
+		case "default-allow-breaking":
+			cfg.Defaults.AllowBreaking = *breaking
+		case "default-allow-placing":
+			cfg.Defaults.AllowPlacing = *placing
+		case "default-avoid-water":
+			cfg.Defaults.AvoidWater = *avoidWater
+		case "default-minimise-vertical":
+			cfg.Defaults.MinimiseVertical = *minVertical
+		case "default-max-iterations":
+			cfg.Defaults.MaxIterations = *maxIterations
+		}
+	})
+
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		values, err := parseYAML(data)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if data, err = json.Marshal(values); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case ".json":
+	default:
+		return fmt.Errorf("config file %s must end in .json, .yaml or .yml", path)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Config) applyEnv(getenv func(string) string) error {
+	str := func(name string, dst *string) {
+		if v := getenv(envPrefix + name); v != "" {
+			*dst = v
+		}
+	}
+
+	var errs []error
+
+	integer := func(name string, dst *int) {
+		if v := getenv(envPrefix + name); v != "" {
+			parsed, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %q is not an integer", envPrefix, name, v))
+				return
+			}
+			*dst = parsed
+		}
+	}
+
+	boolean := func(name string, dst *bool) {
+		if v := getenv(envPrefix + name); v != "" {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %q is not a boolean", envPrefix, name, v))
+				return
+			}
+			*dst = parsed
+		}
+	}
+
+	number := func(name string, dst *float64) {
+		if v := getenv(envPrefix + name); v != "" {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %q is not a number", envPrefix, name, v))
+				return
+			}
+			*dst = parsed
+		}
+	}
+
+	duration := func(name string, dst *Duration) {
+		if v := getenv(envPrefix + name); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %q is not a duration", envPrefix, name, v))
+				return
+			}
+			dst.Duration = parsed
+		}
+	}
+
+	str("LISTEN", &c.Listen)
+	str("FRONTEND_DIR", &c.FrontendDir)
+	if v := getenv(envPrefix + "ALLOWED_ORIGINS"); v != "" {
+		c.AllowedOrigins = splitList(v)
+	}
+	duration("READ_TIMEOUT", &c.ReadTimeout)
+	duration("WRITE_TIMEOUT", &c.WriteTimeout)
+	duration("SEARCH_TIMEOUT", &c.SearchTimeout)
+	duration("IDLE_TIMEOUT", &c.IdleTimeout)
+	duration("SHUTDOWN_DELAY", &c.ShutdownDelay)
+	duration("SHUTDOWN_TIMEOUT", &c.ShutdownTimeout)
+	integer("MAX_WORLD_VOLUME", &c.MaxWorldVolume)
+	integer("MAX_WORLD_BLOCKS", &c.MaxWorldBlocks)
+	integer("MAX_CONCURRENT_SEARCHES", &c.MaxConcurrentSearches)
+	integer("MAX_BODY_BYTES", &c.MaxBodyBytes)
+	integer("MAX_BATCH_QUERIES", &c.MaxBatchQueries)
+	integer("MAX_SEARCH_NODES", &c.MaxSearchNodes)
+	integer("MAX_SEARCH_FRONTIER", &c.MaxSearchFrontier)
+	integer("MAX_SEARCH_ITERATIONS", &c.MaxSearchIterations)
+	integer("RESULT_CACHE_SIZE", &c.ResultCacheSize)
+	number("RATE_LIMIT", &c.RateLimit)
+	integer("RATE_BURST", &c.RateBurst)
+	str("LOG_LEVEL", &c.LogLevel)
+	str("LOG_FORMAT", &c.LogFormat)
+	str("DEFAULT_ALGORITHM", &c.Defaults.Algorithm)
+	boolean("DEFAULT_ALLOW_BREAKING", &c.Defaults.AllowBreaking)
+	boolean("DEFAULT_ALLOW_PLACING", &c.Defaults.AllowPlacing)
+	boolean("DEFAULT_AVOID_WATER", &c.Defaults.AvoidWater)
+	boolean("DEFAULT_MINIMISE_VERTICAL", &c.Defaults.MinimiseVertical)
+	integer("DEFAULT_MAX_ITERATIONS", &c.Defaults.MaxIterations)
+
+	return errors.Join(errs...)
+}
+
+func (c Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.Listen); err != nil {
+		errs = append(errs, fmt.Errorf("listen: %w", err))
+	}
+
+	if len(c.AllowedOrigins) == 0 {
+		errs = append(errs, errors.New("allowedOrigins: at least one origin is required, use \"*\" to allow any"))
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs = append(errs, fmt.Errorf("allowedOrigins: %q must look like https://example.com", origin))
+		}
+	}
+
+	if c.ReadTimeout.Duration < 0 {
+		errs = append(errs, errors.New("readTimeout: must not be negative"))
+	}
+	if c.WriteTimeout.Duration < 0 {
+		errs = append(errs, errors.New("writeTimeout: must not be negative"))
+	}
+	if c.SearchTimeout.Duration < 0 {
+		errs = append(errs, errors.New("searchTimeout: must not be negative"))
+	}
+	if c.IdleTimeout.Duration < 0 {
+		errs = append(errs, errors.New("idleTimeout: must not be negative"))
+	}
+	if c.ShutdownDelay.Duration < 0 {
+		errs = append(errs, errors.New("shutdownDelay: must not be negative"))
+	}
+	if c.ShutdownTimeout.Duration < 0 {
+		errs = append(errs, errors.New("shutdownTimeout: must not be negative"))
+	}
+	if c.WriteTimeout.Duration > 0 && c.SearchTimeout.Duration > c.WriteTimeout.Duration {
+		errs = append(errs, errors.New("searchTimeout: must not exceed writeTimeout"))
+	}
+
+	if c.MaxWorldVolume <= 0 {
+		errs = append(errs, errors.New("maxWorldVolume: must be positive"))
+	}
+	if c.MaxWorldBlocks <= 0 {
+		errs = append(errs, errors.New("maxWorldBlocks: must be positive"))
+	}
+	if c.MaxConcurrentSearches <= 0 {
+		errs = append(errs, errors.New("maxConcurrentSearches: must be positive"))
+	}
+	if c.MaxBodyBytes <= 0 {
+		errs = append(errs, errors.New("maxBodyBytes: must be positive"))
+	}
+	if c.MaxBatchQueries <= 0 {
+		errs = append(errs, errors.New("maxBatchQueries: must be positive"))
+	}
+	if c.MaxSearchNodes <= 0 {
+		errs = append(errs, errors.New("maxSearchNodes: must be positive"))
+	}
+	if c.MaxSearchFrontier <= 0 {
+		errs = append(errs, errors.New("maxSearchFrontier: must be positive"))
+	}
+	if c.ResultCacheSize < 0 {
+		errs = append(errs, errors.New("resultCacheSize: must not be negative"))
+	}
+	if c.MaxSearchIterations <= 0 {
+		errs = append(errs, errors.New("maxSearchIterations: must be positive"))
+	}
+	if c.RateLimit < 0 {
+		errs = append(errs, errors.New("rateLimit: must not be negative"))
+	}
+	if c.RateLimit > 0 && c.RateBurst <= 0 {
+		errs = append(errs, errors.New("rateBurst: must be positive when rateLimit is set"))
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("logLevel: %q must be one of debug, info, warn or error", c.LogLevel))
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("logFormat: %q must be text or json", c.LogFormat))
+	}
+
+	if _, ok := pathfinding.LookupAlgorithm(c.Defaults.Algorithm); !ok {
+		errs = append(errs, fmt.Errorf("defaults.algorithm: unknown algorithm %q, expected one of %s",
+			c.Defaults.Algorithm, strings.Join(pathfinding.AlgorithmNames(), ", ")))
+	}
+	if c.Defaults.Algorithm == "jps" && (c.Defaults.AllowBreaking || c.Defaults.AllowPlacing) {
+		errs = append(errs, errors.New("defaults: jump point search does not support breaking or placing blocks"))
+	}
+	if c.Defaults.MaxIterations <= 0 {
+		errs = append(errs, errors.New("defaults.maxIterations: must be positive"))
+	}
+	if c.Defaults.MaxIterations > c.MaxSearchIterations {
+		errs = append(errs, errors.New("defaults.maxIterations: must not exceed maxSearchIterations"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c Config) Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+func (c Config) Describe() string {
+	var b strings.Builder
+
+	line := func(name string, value interface{}) {
+		fmt.Fprintf(&b, "  %-26s %v\n", name, value)
+	}
+
+	frontend := c.FrontendDir
+	if frontend == "" {
+		frontend = "(embedded)"
+	}
+
+	b.WriteString("Effective configuration:\n")
+	line("listen", c.Listen)
+	line("frontendDir", frontend)
+	line("allowedOrigins", strings.Join(c.AllowedOrigins, ", "))
+	line("readTimeout", c.ReadTimeout)
+	line("writeTimeout", c.WriteTimeout)
+	line("searchTimeout", c.SearchTimeout)
+	line("idleTimeout", c.IdleTimeout)
+	line("shutdownDelay", c.ShutdownDelay)
+	line("shutdownTimeout", c.ShutdownTimeout)
+	line("maxWorldVolume", c.MaxWorldVolume)
+	line("maxWorldBlocks", c.MaxWorldBlocks)
+	line("maxConcurrentSearches", c.MaxConcurrentSearches)
+	line("maxBodyBytes", c.MaxBodyBytes)
+	line("maxBatchQueries", c.MaxBatchQueries)
+	line("maxSearchNodes", c.MaxSearchNodes)
+	line("maxSearchFrontier", c.MaxSearchFrontier)
+	line("maxSearchIterations", c.MaxSearchIterations)
+	if c.ResultCacheSize > 0 {
+		line("resultCacheSize", c.ResultCacheSize)
+	} else {
+		line("resultCacheSize", "(disabled)")
+	}
+	if c.RateLimit > 0 {
+		line("rateLimit", fmt.Sprintf("%g/s per client, burst %d", c.RateLimit, c.RateBurst))
+	} else {
+		line("rateLimit", "(disabled)")
+	}
+	line("logLevel", c.LogLevel)
+	line("logFormat", c.LogFormat)
+	line("defaults.algorithm", c.Defaults.Algorithm)
+	line("defaults.allowBreaking", c.Defaults.AllowBreaking)
+	line("defaults.allowPlacing", c.Defaults.AllowPlacing)
+	line("defaults.avoidWater", c.Defaults.AvoidWater)
+	line("defaults.minimiseVertical", c.Defaults.MinimiseVertical)
+	line("defaults.maxIterations", c.Defaults.MaxIterations)
+
+	return b.String()
+}
+
+func (c Config) AllowsOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Config) AllowsAnyOrigin() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
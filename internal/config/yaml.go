@@ -0,0 +1,214 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	var lines []yamlLine
+
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := stripComment(raw)
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "---" {
+			continue
+		}
+		if strings.Contains(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+
+		trimmed := strings.TrimLeft(text, " ")
+		lines = append(lines, yamlLine{
+			number: i + 1,
+			indent: len(text) - len(trimmed),
+			text:   strings.TrimRight(trimmed, " "),
+		})
+	}
+
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	value, rest, err := parseYAMLBlock(lines, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("line %d: unexpected indentation", rest[0].number)
+	}
+
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("top level must be a mapping")
+	}
+	return root, nil
+}
+
+func parseYAMLBlock(lines []yamlLine, indent int) (interface{}, []yamlLine, error) {
+	if strings.HasPrefix(lines[0].text, "- ") || lines[0].text == "-" {
+		return parseYAMLList(lines, indent)
+	}
+	return parseYAMLMap(lines, indent)
+}
+
+func parseYAMLList(lines []yamlLine, indent int) (interface{}, []yamlLine, error) {
+	var list []interface{}
+
+	for len(lines) > 0 && lines[0].indent == indent {
+		line := lines[0]
+		if !strings.HasPrefix(line.text, "-") {
+			return nil, nil, fmt.Errorf("line %d: expected a list item", line.number)
+		}
+
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if item == "" {
+			return nil, nil, fmt.Errorf("line %d: nested list items are not supported", line.number)
+		}
+
+		value, err := parseYAMLScalar(item)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		list = append(list, value)
+		lines = lines[1:]
+	}
+
+	return list, lines, nil
+}
+
+func parseYAMLMap(lines []yamlLine, indent int) (interface{}, []yamlLine, error) {
+	result := make(map[string]interface{})
+
+	for len(lines) > 0 && lines[0].indent == indent {
+		line := lines[0]
+
+		key, value, found := strings.Cut(line.text, ":")
+		if !found {
+			return nil, nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+		key = unquote(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if _, exists := result[key]; exists {
+			return nil, nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+
+		lines = lines[1:]
+
+		if value != "" {
+			parsed, err := parseYAMLScalar(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			result[key] = parsed
+			continue
+		}
+
+		if len(lines) == 0 || lines[0].indent <= indent {
+			result[key] = nil
+			continue
+		}
+
+		nested, rest, err := parseYAMLBlock(lines, lines[0].indent)
+		if err != nil {
+			return nil, nil, err
+		}
+		result[key] = nested
+		lines = rest
+	}
+
+	if len(lines) > 0 && lines[0].indent > indent {
+		return nil, nil, fmt.Errorf("line %d: unexpected indentation", lines[0].number)
+	}
+
+	return result, lines, nil
+}
+
+func parseYAMLScalar(value string) (interface{}, error) {
+	if strings.HasPrefix(value, "[") {
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("unterminated inline list %q", value)
+		}
+
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		list := []interface{}{}
+		if inner == "" {
+			return list, nil
+		}
+
+		for _, item := range strings.Split(inner, ",") {
+			parsed, err := parseYAMLScalar(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, parsed)
+		}
+		return list, nil
+	}
+
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return unquote(value), nil
+	}
+
+	switch value {
+	case "true", "yes", "on":
+		return true, nil
+	case "false", "no", "off":
+		return false, nil
+	case "null", "~":
+		return nil, nil
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+
+	return value, nil
+}
+
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+
+	for i, r := range line {
+		switch r {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '#':
+			if !inSingle && !inDouble && (i == 0 || line[i-1] == ' ') {
+				return line[:i]
+			}
+		}
+	}
+
+	return line
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			if value[0] == '"' {
+				if s, err := strconv.Unquote(value); err == nil {
+					return s
+				}
+			}
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
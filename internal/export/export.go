@@ -0,0 +1,226 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type Path struct {
+	Algorithm     string
+	Points        []pathfinding.Point
+	BlocksBroken  []pathfinding.Point
+	BlocksPlaced  []pathfinding.Point
+	TotalCost     float64
+	AllowBreaking bool
+	AllowPlacing  bool
+}
+
+type Waypoint struct {
+	Step   int  `json:"step"`
+	X      int  `json:"x"`
+	Y      int  `json:"y"`
+	Z      int  `json:"z"`
+	Broken bool `json:"broken"`
+	Placed bool `json:"placed"`
+}
+
+func (p Path) Waypoints() []Waypoint {
+	broken := pointSet(p.BlocksBroken)
+	placed := pointSet(p.BlocksPlaced)
+
+	waypoints := make([]Waypoint, len(p.Points))
+	for i, point := range p.Points {
+		waypoints[i] = Waypoint{
+			Step: i, X: point.X, Y: point.Y, Z: point.Z,
+			Broken: broken[point], Placed: placed[point],
+		}
+	}
+	return waypoints
+}
+
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	Write       func(w io.Writer, p Path) error
+}
+
+var formats = []Format{
+	{Name: "csv", ContentType: "text/csv; charset=utf-8", Extension: "csv", Write: writeCSV},
+	{Name: "jsonl", ContentType: "application/x-ndjson", Extension: "jsonl", Write: writeJSONL},
+	{Name: "mcfunction", ContentType: "text/x-mcfunction; charset=utf-8", Extension: "mcfunction", Write: writeMCFunction},
+	{Name: "baritone", ContentType: "text/x-baritone; charset=utf-8", Extension: "txt", Write: writeBaritone},
+}
+
+func Formats() []Format {
+	return append([]Format(nil), formats...)
+}
+
+func Names() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func Lookup(name string) (Format, bool) {
+	for _, f := range formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+func ForMediaType(mediaType string) (Format, bool) {
+	for _, f := range formats {
+		base, _, _ := strings.Cut(f.ContentType, ";")
+		if strings.EqualFold(base, mediaType) {
+			return f, true
+		}
+	}
+	if strings.EqualFold(mediaType, "application/jsonl") {
+		return Lookup("jsonl")
+	}
+	return Format{}, false
+}
+
+func writeCSV(w io.Writer, p Path) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"step", "x", "y", "z", "broken", "placed"}); err != nil {
+		return err
+	}
+
+	for _, wp := range p.Waypoints() {
+		record := []string{
+			strconv.Itoa(wp.Step),
+			strconv.Itoa(wp.X), strconv.Itoa(wp.Y), strconv.Itoa(wp.Z),
+			strconv.FormatBool(wp.Broken), strconv.FormatBool(wp.Placed),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func writeJSONL(w io.Writer, p Path) error {
+	encoder := json.NewEncoder(w)
+	for _, wp := range p.Waypoints() {
+		if err := encoder.Encode(wp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMCFunction(w io.Writer, p Path) error {
+	out := bufio.NewWriter(w)
+
+	if p.Algorithm != "" {
+		fmt.Fprintf(out, "# Paritone path found by %s\n", p.Algorithm)
+	}
+	fmt.Fprintf(out, "# %d waypoints, total cost %.2f\n", len(p.Points), p.TotalCost)
+	fmt.Fprintln(out, "# Run with /function after copying into a datapack; coordinates are absolute.")
+
+	if len(p.Points) > 0 {
+		start, goal := p.Points[0], p.Points[len(p.Points)-1]
+		fmt.Fprintf(out, "setblock %d %d %d minecraft:emerald_block\n", start.X, start.Y-1, start.Z)
+		fmt.Fprintf(out, "setblock %d %d %d minecraft:redstone_block\n", goal.X, goal.Y-1, goal.Z)
+	}
+
+	for _, wp := range p.Waypoints() {
+		particle := "minecraft:end_rod"
+		switch {
+		case wp.Broken:
+			particle = "minecraft:crit"
+		case wp.Placed:
+			particle = "minecraft:happy_villager"
+		}
+		fmt.Fprintf(out, "particle %s %.1f %.1f %.1f 0 0 0 0 1 force\n", particle,
+			float64(wp.X)+0.5, float64(wp.Y)+0.5, float64(wp.Z)+0.5)
+	}
+
+	return out.Flush()
+}
+
+func writeBaritone(w io.Writer, p Path) error {
+	out := bufio.NewWriter(w)
+
+	if p.Algorithm != "" {
+		fmt.Fprintf(out, "// Paritone path found by %s\n", p.Algorithm)
+	}
+	fmt.Fprintf(out, "// %d path points, total cost %.2f\n", len(p.Points), p.TotalCost)
+
+	turns := turningPoints(p.Points)
+	if len(turns) == 0 {
+		return out.Flush()
+	}
+
+	fmt.Fprintln(out, "// Every line not starting with // is a Baritone command. The waypoints mark each turn of the path")
+	fmt.Fprintln(out, "// and #goal sets its end without moving. #path then lets Baritone find its own way there; to follow")
+	fmt.Fprintf(out, "// this path instead, run #wp goto paritone-1, then paritone-2 and so on up to paritone-%d.\n", len(turns))
+	if p.AllowBreaking || len(p.BlocksBroken) > 0 {
+		fmt.Fprintln(out, "// The path breaks blocks, which needs #set allowBreak true. That setting stays on for later goals.")
+	}
+	if p.AllowPlacing || len(p.BlocksPlaced) > 0 {
+		fmt.Fprintln(out, "// The path places blocks, which needs #set allowPlace true. That setting stays on for later goals.")
+	}
+
+	for i, point := range turns {
+		fmt.Fprintf(out, "#wp save user paritone-%d %d %d %d\n", i+1, point.X, point.Y, point.Z)
+	}
+	goal := turns[len(turns)-1]
+	fmt.Fprintf(out, "#goal %d %d %d\n", goal.X, goal.Y, goal.Z)
+
+	return out.Flush()
+}
+
+func turningPoints(points []pathfinding.Point) []pathfinding.Point {
+	if len(points) <= 2 {
+		if len(points) == 2 {
+			return points[1:]
+		}
+		return nil
+	}
+
+	var turns []pathfinding.Point
+	for i := 1; i < len(points)-1; i++ {
+		if direction(points[i-1], points[i]) != direction(points[i], points[i+1]) {
+			turns = append(turns, points[i])
+		}
+	}
+	return append(turns, points[len(points)-1])
+}
+
+func direction(a, b pathfinding.Point) pathfinding.Point {
+	return pathfinding.Point{X: sign(b.X - a.X), Y: sign(b.Y - a.Y), Z: sign(b.Z - a.Z)}
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func pointSet(points []pathfinding.Point) map[pathfinding.Point]bool {
+	set := make(map[pathfinding.Point]bool, len(points))
+	for _, p := range points {
+		set[p] = true
+	}
+	return set
+}
@@ -0,0 +1,416 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound  = errors.New("job not found")
+	ErrQueueFull = errors.New("job queue is full")
+	ErrClosed    = errors.New("job manager is closed")
+
+	errCancelledBeforeStart  = errors.New("cancelled before start")
+	errCancelledWhileRunning = errors.New("cancelled while running")
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusDone      Status = "done"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+func (s Status) Finished() bool {
+	return s == StatusDone || s == StatusFailed || s == StatusCancelled
+}
+
+type Task func(ctx context.Context, report func(progress interface{})) (interface{}, error)
+
+type Config struct {
+	Workers     int
+	QueueSize   int
+	Retention   time.Duration
+	MaxRetained int
+}
+
+type Snapshot struct {
+	ID          string      `json:"id"`
+	Kind        string      `json:"kind"`
+	Status      Status      `json:"status"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	StartedAt   *time.Time  `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time  `json:"finishedAt,omitempty"`
+	Progress    interface{} `json:"progress,omitempty"`
+	Result      interface{} `json:"result,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	ErrorCode   string      `json:"errorCode,omitempty"`
+}
+
+type job struct {
+	id   string
+	kind string
+	task Task
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	status      Status
+	submittedAt time.Time
+	startedAt   time.Time
+	finishedAt  time.Time
+	progress    interface{}
+	result      interface{}
+	err         string
+	errCode     string
+}
+
+func (j *job) snapshot() Snapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	s := Snapshot{
+		ID:          j.id,
+		Kind:        j.kind,
+		Status:      j.status,
+		SubmittedAt: j.submittedAt,
+		Progress:    j.progress,
+		Result:      j.result,
+		Error:       j.err,
+		ErrorCode:   j.errCode,
+	}
+	if !j.startedAt.IsZero() {
+		started := j.startedAt
+		s.StartedAt = &started
+	}
+	if !j.finishedAt.IsZero() {
+		finished := j.finishedAt
+		s.FinishedAt = &finished
+	}
+
+	return s
+}
+
+func (j *job) report(progress interface{}) {
+	j.mu.Lock()
+	j.progress = progress
+	j.mu.Unlock()
+}
+
+func (j *job) finish(status Status, result interface{}, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status.Finished() {
+		return
+	}
+	j.status = status
+	j.result = result
+	j.finishedAt = time.Now()
+
+	if err != nil {
+		j.err = err.Error()
+
+		var coded interface{ ErrorCode() string }
+		if errors.As(err, &coded) {
+			j.errCode = coded.ErrorCode()
+		}
+	}
+}
+
+type Manager struct {
+	config Config
+	queue  chan *job
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+func NewManager(config Config) *Manager {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize < 0 {
+		config.QueueSize = 0
+	}
+
+	m := &Manager{
+		config: config,
+		queue:  make(chan *job, config.QueueSize),
+		jobs:   make(map[string]*job),
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+
+	return m
+}
+
+func (m *Manager) Submit(kind string, task Task) (Snapshot, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := &job{
+		id:          newID(),
+		kind:        kind,
+		task:        task,
+		ctx:         ctx,
+		cancel:      cancel,
+		status:      StatusQueued,
+		submittedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		cancel()
+		return Snapshot{}, ErrClosed
+	}
+
+	m.pruneLocked(j.submittedAt)
+
+	select {
+	case m.queue <- j:
+	default:
+		cancel()
+		return Snapshot{}, fmt.Errorf("%w: %d jobs waiting", ErrQueueFull, len(m.queue))
+	}
+
+	m.jobs[j.id] = j
+	return j.snapshot(), nil
+}
+
+func (m *Manager) Get(id string) (Snapshot, error) {
+	m.mu.Lock()
+	j, exists := m.jobs[id]
+	m.mu.Unlock()
+
+	if !exists {
+		return Snapshot{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	return j.snapshot(), nil
+}
+
+func (m *Manager) Cancel(id string) (Snapshot, error) {
+	m.mu.Lock()
+	j, exists := m.jobs[id]
+	m.mu.Unlock()
+
+	if !exists {
+		return Snapshot{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	j.cancel()
+
+	j.mu.Lock()
+	queued := j.status == StatusQueued
+	j.mu.Unlock()
+	if queued {
+		j.finish(StatusCancelled, nil, errCancelledBeforeStart)
+	}
+
+	return j.snapshot(), nil
+}
+
+func (m *Manager) List() []Snapshot {
+	m.mu.Lock()
+	snapshots := make([]Snapshot, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		snapshots = append(snapshots, j.snapshot())
+	}
+	m.mu.Unlock()
+
+	sort.Slice(snapshots, func(i, k int) bool {
+		return snapshots[i].SubmittedAt.Before(snapshots[k].SubmittedAt)
+	})
+
+	return snapshots
+}
+
+func (m *Manager) Prune(now time.Time) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pruneLocked(now)
+}
+
+func (m *Manager) pruneLocked(now time.Time) []string {
+	type finishedJob struct {
+		id string
+		at time.Time
+	}
+
+	var finished []finishedJob
+	var pruned []string
+
+	for id, j := range m.jobs {
+		j.mu.Lock()
+		done, at := j.status.Finished(), j.finishedAt
+		j.mu.Unlock()
+
+		if !done {
+			continue
+		}
+		if m.config.Retention > 0 && now.Sub(at) > m.config.Retention {
+			delete(m.jobs, id)
+			pruned = append(pruned, id)
+			continue
+		}
+		finished = append(finished, finishedJob{id, at})
+	}
+
+	if m.config.MaxRetained > 0 && len(finished) > m.config.MaxRetained {
+		sort.Slice(finished, func(i, k int) bool {
+			return finished[i].at.Before(finished[k].at)
+		})
+		for _, f := range finished[:len(finished)-m.config.MaxRetained] {
+			delete(m.jobs, f.id)
+			pruned = append(pruned, f.id)
+		}
+	}
+
+	return pruned
+}
+
+func (m *Manager) Janitor(interval time.Duration, onPrune func(ids []string)) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				if pruned := m.Prune(now); len(pruned) > 0 && onPrune != nil {
+					onPrune(pruned)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (m *Manager) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	for _, j := range m.jobs {
+		j.cancel()
+	}
+	close(m.queue)
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+func (m *Manager) Shutdown(ctx context.Context) int {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+	}
+
+	interrupted := 0
+
+	m.mu.Lock()
+	for _, j := range m.jobs {
+		j.mu.Lock()
+		if !j.status.Finished() {
+			interrupted++
+		}
+		j.mu.Unlock()
+		j.cancel()
+	}
+	m.mu.Unlock()
+
+	<-done
+	return interrupted
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+
+	for j := range m.queue {
+		m.run(j)
+	}
+}
+
+func (m *Manager) run(j *job) {
+	j.mu.Lock()
+	if j.status != StatusQueued {
+		j.mu.Unlock()
+		return
+	}
+	if j.ctx.Err() != nil {
+		j.mu.Unlock()
+		j.finish(StatusCancelled, nil, errCancelledBeforeStart)
+		return
+	}
+	j.status = StatusRunning
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+
+	defer j.cancel()
+
+	result, err := m.execute(j)
+
+	switch {
+	case j.ctx.Err() != nil:
+		j.finish(StatusCancelled, nil, errCancelledWhileRunning)
+	case err != nil:
+		j.finish(StatusFailed, nil, err)
+	default:
+		j.finish(StatusDone, result, nil)
+	}
+}
+
+func (m *Manager) execute(j *job) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return j.task(j.ctx, j.report)
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("j%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,302 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+type Registry struct {
+	mu         sync.Mutex
+	names      map[string]bool
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+func (r *Registry) register(name string, c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.names[name] = true
+	r.collectors = append(r.collectors, c)
+}
+
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	buf := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(buf)
+	}
+	return buf.Flush()
+}
+
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (d desc) header(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (d desc) labelPairs(values []string, extra ...string) string {
+	var pairs []string
+	for i, label := range d.labels {
+		pairs = append(pairs, label+`="`+escapeLabel(values[i])+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*sample
+}
+
+type sample struct {
+	labels []string
+	value  float64
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, kind: "counter", labels: labels},
+		values: make(map[string]*sample),
+	}
+	r.register(name, c)
+	return c
+}
+
+func (c *CounterVec) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		panic("metrics: counters cannot decrease")
+	}
+
+	key := c.key(labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.values[key]
+	if !ok {
+		s = &sample{labels: append([]string(nil), labels...)}
+		c.values[key] = s
+	}
+	s.value += delta
+}
+
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.header(w)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range sortedKeys(c.values) {
+		s := c.values[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(s.labels), formatFloat(s.value))
+	}
+}
+
+type Gauge struct {
+	desc
+	mu    sync.Mutex
+	value float64
+}
+
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	g := &Gauge{desc: desc{name: name, help: help, kind: "gauge"}}
+	r.register(name, g)
+	return g
+}
+
+func (g *Gauge) Add(delta float64) {
+	g.mu.Lock()
+	g.value += delta
+	g.mu.Unlock()
+}
+
+func (g *Gauge) Inc() { g.Add(1) }
+
+func (g *Gauge) Dec() { g.Add(-1) }
+
+func (g *Gauge) Set(value float64) {
+	g.mu.Lock()
+	g.value = value
+	g.mu.Unlock()
+}
+
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+func (g *Gauge) write(w *bufio.Writer) {
+	g.header(w)
+
+	g.mu.Lock()
+	value := g.value
+	g.mu.Unlock()
+
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(value))
+}
+
+type GaugeFunc struct {
+	desc
+	fn func() float64
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help, kind: "gauge"}, fn: fn}
+	r.register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.header(w)
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+}
+
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	labels []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labels: labels},
+		buckets: buckets,
+		values:  make(map[string]*histogram),
+	}
+	r.register(name, h)
+	return h
+}
+
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	key := h.key(labels)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.values[key]
+	if !ok {
+		s = &histogram{labels: append([]string(nil), labels...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = s
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.header(w)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, key := range sortedKeys(h.values) {
+		s := h.values[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.labels, "le", formatFloat(bound)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(s.labels), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(s.labels), s.count)
+	}
+}
+
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,55 @@
+package pathfinding
+
+type SearchFunc func(start, goal Point, world World, options PathfindingOptions) PathfindingResult
+
+type Algorithm struct {
+	Name    string
+	Label   string
+	Optimal bool
+	Search  SearchFunc
+}
+
+var algorithms = []Algorithm{
+	{Name: "astar", Label: "A*", Optimal: true, Search: withSearchStats(FindPathWithOptions)},
+	{Name: "dijkstra", Label: "Dijkstra", Optimal: true, Search: withSearchStats(FindPathDijkstraWithOptions)},
+	{Name: "bfs", Label: "BFS", Search: withSearchStats(FindPathBFSWithOptions)},
+	{Name: "greedy", Label: "Greedy Best-First", Search: withSearchStats(FindPathGreedyWithOptions)},
+	{Name: "jps", Label: "Jump Point Search", Search: withSearchStats(FindPathJPSWithOptions)},
+	{Name: "ida", Label: "IDA*", Optimal: true, Search: withSearchStats(FindPathIDAWithOptions)},
+	{Name: "bellmanford", Label: "Bellman-Ford", Optimal: true, Search: withSearchStats(FindPathBellmanFordWithOptions)},
+	{Name: "theta", Label: "Theta*", Search: withSearchStats(FindPathThetaStarWithOptions)},
+	{Name: "bidirectional", Label: "Bidirectional BFS", Search: withSearchStats(FindPathBidirectionalWithOptions)},
+}
+
+func withSearchStats(search SearchFunc) SearchFunc {
+	return func(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+		stats := &searchStats{}
+		options.stats = stats
+
+		result := search(start, goal, world, options)
+		result.MaxMemoryUsed = max(result.MaxMemoryUsed, stats.peakFrontier)
+
+		return result
+	}
+}
+
+func Algorithms() []Algorithm {
+	return append([]Algorithm(nil), algorithms...)
+}
+
+func AlgorithmNames() []string {
+	names := make([]string, len(algorithms))
+	for i, algorithm := range algorithms {
+		names[i] = algorithm.Name
+	}
+	return names
+}
+
+func LookupAlgorithm(name string) (Algorithm, bool) {
+	for _, algorithm := range algorithms {
+		if algorithm.Name == name {
+			return algorithm, true
+		}
+	}
+	return Algorithm{}, false
+}
@@ -120,6 +120,10 @@ func GetNeighborsWithBreaking(p Point, world World, options PathfindingOptions)
 }
 
 func FindPath(start, goal Point, world World) []Point {
+	return aStarSearch(start, goal, world, nil)
+}
+
+func aStarSearch(start, goal Point, world World, monitor *searchMonitor) []Point {
 	openSet := &PriorityQueue{}
 	heap.Init(openSet)
 
@@ -136,9 +140,15 @@ func FindPath(start, goal Point, world World) []Point {
 	gScore := make(map[Point]float64)
 	gScore[start] = 0
 
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			return nil
+		}
+
 		if current.Position.IsEqual(goal) {
 
 			path := []Point{}
@@ -193,10 +203,17 @@ func findPathWithBreaking(start, goal Point, world World, options PathfindingOpt
 	waterCrossed := 0
 	nodesExplored := 0
 
+	monitor := newSearchMonitor(options)
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 		nodesExplored++
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			break
+		}
+
 		if current.Position.IsEqual(goal) {
 
 			path := []Point{}
@@ -65,10 +65,16 @@ func FindPathBellmanFord(start, goal Point, world World) []Point {
 	return path
 }
 
+const maxBellmanFordVertices = 5000
+
 func FindPathBellmanFordWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchBellmanFord)
+}
+
+func searchBellmanFord(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
-	vertices := getLocalWalkableVertices(start, goal, world, options)
+	vertices, truncated := getLocalWalkableVertices(start, goal, world, options)
 
 	dist := make(map[Point]float64)
 	pred := make(map[Point]Point)
@@ -86,77 +92,97 @@ func FindPathBellmanFordWithOptions(start, goal Point, world World, options Path
 
 	maxMemoryUsed := len(vertices)
 
-	for i := 0; i < len(vertices)-1; i++ {
-		anyUpdate := false
+	monitor := newSearchMonitor(options)
 
-		for _, u := range vertices {
+	neighborsOf := func(u Point) []Point {
+		var neighbors []Point
 
-			if dist[u] == math.Inf(1) {
-				continue
-			}
+		if options.AllowBreaking {
 
-			nodesExplored++
+			for _, dir := range []Point{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
+				neighbor := Point{u.X + dir.X, u.Y + dir.Y, u.Z + dir.Z}
+
+				if world.IsWalkable(neighbor) {
+					neighbors = append(neighbors, neighbor)
+				} else if world.CanBreak(neighbor) {
+					neighbors = append(neighbors, neighbor)
+					breakPoints[neighbor] = true
+				}
+			}
+		} else if options.AllowPlacing {
 
-			var neighbors []Point
+			for _, dir := range []Point{{1, 0, 0}, {-1, 0, 0}, {0, 0, 1}, {0, 0, -1}} {
 
-			if options.AllowBreaking {
+				neighbor := Point{u.X + dir.X, u.Y + dir.Y, u.Z + dir.Z}
+				if world.IsWalkable(neighbor) {
+					neighbors = append(neighbors, neighbor)
+				}
 
-				for _, dir := range []Point{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
-					neighbor := Point{u.X + dir.X, u.Y + dir.Y, u.Z + dir.Z}
+				placingNeighbor := Point{u.X + dir.X*2, u.Y, u.Z + dir.Z*2}
+				if !world.IsWalkable(placingNeighbor) {
 
-					if world.IsWalkable(neighbor) {
-						neighbors = append(neighbors, neighbor)
-					} else if world.CanBreak(neighbor) {
-						neighbors = append(neighbors, neighbor)
-						breakPoints[neighbor] = true
+					below := Point{placingNeighbor.X, placingNeighbor.Y - 1, placingNeighbor.Z}
+					if world.GetBlockType(below) != "air" {
+						neighbors = append(neighbors, placingNeighbor)
+						placePoints[placingNeighbor] = true
 					}
 				}
-			} else if options.AllowPlacing {
+			}
+		} else {
 
-				for _, dir := range []Point{{1, 0, 0}, {-1, 0, 0}, {0, 0, 1}, {0, 0, -1}} {
+			neighbors = GetWalkableNeighbors(u, world)
+		}
 
-					neighbor := Point{u.X + dir.X, u.Y + dir.Y, u.Z + dir.Z}
-					if world.IsWalkable(neighbor) {
-						neighbors = append(neighbors, neighbor)
-					}
+		return neighbors
+	}
 
-					placingNeighbor := Point{u.X + dir.X*2, u.Y, u.Z + dir.Z*2}
-					if !world.IsWalkable(placingNeighbor) {
+	edgeWeight := func(u, v Point) float64 {
+		weight := world.GetMovementCost(u, v)
 
-						below := Point{placingNeighbor.X, placingNeighbor.Y - 1, placingNeighbor.Z}
-						if world.GetBlockType(below) != "air" {
-							neighbors = append(neighbors, placingNeighbor)
-							placePoints[placingNeighbor] = true
-						}
-					}
-				}
-			} else {
+		if breakPoints[v] {
+			weight += 5.0
+		} else if placePoints[v] {
+			weight += 3.0
+		}
 
-				neighbors = GetWalkableNeighbors(u, world)
-			}
+		if options.AvoidWater && world.GetBlockType(v) == "water" {
+			weight += 10.0
+		}
 
-			for _, v := range neighbors {
+		if options.MinimiseHeight && v.Y != u.Y {
+			weight += 2.0 * float64(abs(v.Y-u.Y))
+		}
 
-				if _, exists := dist[v]; !exists {
-					continue
-				}
+		return weight
+	}
 
-				weight := world.GetMovementCost(u, v)
+	converged := false
 
-				if breakPoints[v] {
-					weight += 5.0
-				} else if placePoints[v] {
-					weight += 3.0
-				}
+relaxation:
+	for i := 0; i < len(vertices)-1; i++ {
+		anyUpdate := false
+		monitor.setIteration(i + 1)
 
-				if options.AvoidWater && world.GetBlockType(v) == "water" {
-					weight += 10.0
-				}
+		for _, u := range vertices {
+
+			if dist[u] == math.Inf(1) {
+				continue
+			}
 
-				if options.MinimiseHeight && v.Y != u.Y {
-					weight += 2.0 * float64(abs(v.Y-u.Y))
+			nodesExplored++
+
+			if !monitor.expand(u, dist[u], len(vertices), nil) {
+				break relaxation
+			}
+
+			for _, v := range neighborsOf(u) {
+
+				if _, exists := dist[v]; !exists {
+					continue
 				}
 
+				weight := edgeWeight(u, v)
+
 				if dist[u]+weight < dist[v] {
 					dist[v] = dist[u] + weight
 					pred[v] = u
@@ -166,38 +192,55 @@ func FindPathBellmanFordWithOptions(start, goal Point, world World, options Path
 		}
 
 		if !anyUpdate {
+			converged = true
 			break
 		}
 	}
 
+	if monitor.stopped() {
+		return PathfindingResult{
+			Path:            nil,
+			NodesExplored:   nodesExplored,
+			ComputationTime: time.Since(startTime),
+			MaxMemoryUsed:   maxMemoryUsed,
+		}
+	}
+
 	for _, u := range vertices {
-		neighbors := GetWalkableNeighbors(u, world)
+		if converged || dist[u] == math.Inf(1) {
+			continue
+		}
 
-		for _, v := range neighbors {
+		for _, v := range neighborsOf(u) {
 			if _, exists := dist[v]; !exists {
 				continue
 			}
 
-			weight := world.GetMovementCost(u, v)
-
-			if dist[u]+weight < dist[v] {
+			if dist[u]+edgeWeight(u, v) < dist[v] {
 
 				return PathfindingResult{
 					Path:            nil,
 					NodesExplored:   nodesExplored,
 					ComputationTime: time.Since(startTime),
 					MaxMemoryUsed:   maxMemoryUsed,
+					Err:             &SearchError{Kind: ErrNegativeCycle, Point: &v},
 				}
 			}
 		}
 	}
 
 	if dist[goal] == math.Inf(1) {
+		var err error
+		if truncated {
+			err = &SearchError{Kind: ErrIterationLimit, Limit: "maxVertices", LimitValue: maxBellmanFordVertices}
+		}
+
 		return PathfindingResult{
 			Path:            nil,
 			NodesExplored:   nodesExplored,
 			ComputationTime: time.Since(startTime),
 			MaxMemoryUsed:   maxMemoryUsed,
+			Err:             err,
 		}
 	}
 
@@ -270,7 +313,7 @@ func getWalkableVertices(start, goal Point, world World) []Point {
 	return vertices
 }
 
-func getLocalWalkableVertices(start, goal Point, world World, options PathfindingOptions) []Point {
+func getLocalWalkableVertices(start, goal Point, world World, options PathfindingOptions) ([]Point, bool) {
 
 	vertices := make(map[Point]bool)
 	vertices[start] = true
@@ -280,9 +323,7 @@ func getLocalWalkableVertices(start, goal Point, world World, options Pathfindin
 	visited := make(map[Point]bool)
 	visited[start] = true
 
-	maxExploration := 5000
-
-	for len(queue) > 0 && len(vertices) < maxExploration {
+	for len(queue) > 0 && len(vertices) < maxBellmanFordVertices {
 		current := queue[0]
 		queue = queue[1:]
 
@@ -319,7 +360,7 @@ func getLocalWalkableVertices(start, goal Point, world World, options Pathfindin
 		result = append(result, v)
 	}
 
-	return result
+	return result, len(queue) > 0
 }
 
 func min(a, b int) int {
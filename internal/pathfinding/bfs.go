@@ -2,6 +2,7 @@ package pathfinding
 
 import (
 	"container/list"
+	"math"
 	"time"
 )
 
@@ -42,6 +43,10 @@ func FindPathBFS(start, goal Point, world World) []Point {
 }
 
 func FindPathBFSWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchBFS)
+}
+
+func searchBFS(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	nodesExplored := 0
@@ -59,11 +64,18 @@ func FindPathBFSWithOptions(start, goal Point, world World, options PathfindingO
 	breakPoints := make(map[Point]bool)
 	placePoints := make(map[Point]bool)
 
+	monitor := newSearchMonitor(options)
+	frontier := listFrontier(queue)
+
 	for queue.Len() > 0 {
 
 		current := queue.Remove(queue.Front()).(Point)
 		nodesExplored++
 
+		if !monitor.expand(current, math.Inf(1), queue.Len(), frontier) {
+			break
+		}
+
 		if current.X == goal.X && current.Y == goal.Y && current.Z == goal.Z {
 
 			path := []Point{}
@@ -2,6 +2,7 @@ package pathfinding
 
 import (
 	"container/list"
+	"math"
 	"time"
 )
 
@@ -89,6 +90,10 @@ func FindPathBidirectional(start, goal Point, world World) []Point {
 }
 
 func FindPathBidirectionalWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchBidirectional)
+}
+
+func searchBidirectional(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	forwardQueue := list.New()
@@ -114,12 +119,19 @@ func FindPathBidirectionalWithOptions(start, goal Point, world World, options Pa
 	var meetingPoint Point
 	meetFound := false
 
-	for forwardQueue.Len() > 0 && backwardQueue.Len() > 0 && !meetFound {
+	monitor := newSearchMonitor(options)
+	frontier := listFrontier(forwardQueue, backwardQueue)
+
+	for forwardQueue.Len() > 0 && backwardQueue.Len() > 0 && !meetFound && !monitor.stopped() {
 
 		if !meetFound && forwardQueue.Len() > 0 {
 			current := forwardQueue.Remove(forwardQueue.Front()).(Point)
 			nodesExplored++
 
+			if !monitor.expand(current, math.Inf(1), forwardQueue.Len()+backwardQueue.Len(), frontier) {
+				break
+			}
+
 			neighbors := getNeighborsWithOptions(current, world, options, breakPoints, placePoints)
 
 			for _, neighbor := range neighbors {
@@ -141,6 +153,10 @@ func FindPathBidirectionalWithOptions(start, goal Point, world World, options Pa
 			current := backwardQueue.Remove(backwardQueue.Front()).(Point)
 			nodesExplored++
 
+			if !monitor.expand(current, math.Inf(1), forwardQueue.Len()+backwardQueue.Len(), frontier) {
+				break
+			}
+
 			neighbors := []Point{}
 			for _, dir := range []Point{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
 				neighbor := Point{current.X + dir.X, current.Y + dir.Y, current.Z + dir.Z}
@@ -0,0 +1,32 @@
+package pathfinding
+
+func PathCost(path []Point, world World, options PathfindingOptions, placed []Point) float64 {
+	placedAt := make(map[Point]bool, len(placed))
+	for _, p := range placed {
+		placedAt[p] = true
+	}
+
+	cost := 0.0
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		step := world.GetMovementCost(from, to)
+
+		if !world.IsWalkable(to) && world.CanBreak(to) {
+			step += 5.0
+		} else if placedAt[to] {
+			step += 3.0
+		}
+
+		if options.AvoidWater && world.GetBlockType(to) == "water" {
+			step += 10.0
+		}
+
+		if options.MinimiseHeight && to.Y != from.Y {
+			step += 2.0 * float64(abs(to.Y-from.Y))
+		}
+
+		cost += step
+	}
+
+	return cost
+}
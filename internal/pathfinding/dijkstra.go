@@ -69,6 +69,10 @@ func FindPathDijkstra(start, goal Point, world World) []Point {
 }
 
 func FindPathDijkstraWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchDijkstra)
+}
+
+func searchDijkstra(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	openSet := &PriorityQueue{}
@@ -97,10 +101,17 @@ func FindPathDijkstraWithOptions(start, goal Point, world World, options Pathfin
 	breakPoints := make(map[Point]bool)
 	placePoints := make(map[Point]bool)
 
+	monitor := newSearchMonitor(options)
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 		nodesExplored++
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			break
+		}
+
 		visited[current.Position] = true
 
 		if current.Position.X == goal.X && current.Position.Y == goal.Y && current.Position.Z == goal.Z {
@@ -0,0 +1,120 @@
+package pathfinding
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoPath         = errors.New("no path found")
+	ErrStartBlocked   = errors.New("start is blocked")
+	ErrGoalBlocked    = errors.New("goal is blocked")
+	ErrIterationLimit = errors.New("iteration limit reached")
+	ErrMemoryLimit    = errors.New("memory limit reached")
+	ErrNegativeCycle  = errors.New("negative cycle detected")
+	ErrCancelled      = errors.New("search cancelled")
+)
+
+type SearchError struct {
+	Kind          error
+	Point         *Point
+	Block         string
+	Limit         string
+	LimitValue    int
+	NodesExplored int
+	Cause         error
+}
+
+func (e *SearchError) Error() string {
+	switch e.Kind {
+	case ErrStartBlocked, ErrGoalBlocked:
+		return fmt.Sprintf("%v: %v is %s", e.Kind, *e.Point, e.Block)
+	case ErrIterationLimit, ErrMemoryLimit:
+		return fmt.Sprintf("%v: %s=%d after exploring %d nodes", e.Kind, e.Limit, e.LimitValue, e.NodesExplored)
+	case ErrNegativeCycle:
+		return fmt.Sprintf("%v through %v", e.Kind, *e.Point)
+	case ErrCancelled:
+		if e.Cause != nil {
+			return fmt.Sprintf("%v after exploring %d nodes: %v", e.Kind, e.NodesExplored, e.Cause)
+		}
+	}
+
+	return fmt.Sprintf("%v after exploring %d nodes", e.Kind, e.NodesExplored)
+}
+
+func (e *SearchError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Kind, e.Cause}
+	}
+	return []error{e.Kind}
+}
+
+func CheckEndpoints(start, goal Point, world World, options PathfindingOptions) error {
+	passable := func(p Point) bool {
+		return world.IsWalkable(p) || (options.AllowBreaking && world.CanBreak(p))
+	}
+
+	if !passable(start) {
+		return &SearchError{Kind: ErrStartBlocked, Point: &start, Block: world.GetBlockType(start)}
+	}
+	if !passable(goal) {
+		return &SearchError{Kind: ErrGoalBlocked, Point: &goal, Block: world.GetBlockType(goal)}
+	}
+
+	return nil
+}
+
+func runSearch(start, goal Point, world World, options PathfindingOptions, search SearchFunc) PathfindingResult {
+	if err := CheckEndpoints(start, goal, world, options); err != nil {
+		return PathfindingResult{Err: err}
+	}
+
+	if options.Logger != nil {
+		options.Logger.Debug("search started",
+			"start", start, "goal", goal,
+			"allowBreaking", options.AllowBreaking, "allowPlacing", options.AllowPlacing,
+			"avoidWater", options.AvoidWater, "minimiseHeight", options.MinimiseHeight,
+			"maxIterations", options.MaxIterations, "maxNodes", options.MaxNodes, "maxFrontier", options.MaxFrontier)
+	}
+
+	if options.stats == nil {
+		options.stats = &searchStats{}
+	}
+
+	result := search(start, goal, world, options)
+
+	if len(result.Path) > 0 {
+		result.Err = nil
+		result.TotalCost = PathCost(result.Path, world, options, result.BlocksPlaced)
+		if options.Logger != nil {
+			options.Logger.Debug("search finished",
+				"pathLength", len(result.Path), "totalCost", result.TotalCost,
+				"nodesExplored", result.NodesExplored, "duration", result.ComputationTime)
+		}
+		return result
+	}
+
+	if result.Err == nil && options.stats.limitErr != nil {
+		result.Err = options.stats.limitErr
+	}
+
+	if result.Err == nil {
+		if options.Context != nil && options.Context.Err() != nil {
+			result.Err = &SearchError{Kind: ErrCancelled, Cause: options.Context.Err()}
+		} else {
+			result.Err = &SearchError{Kind: ErrNoPath}
+		}
+	}
+
+	var searchErr *SearchError
+	if errors.As(result.Err, &searchErr) {
+		searchErr.NodesExplored = result.NodesExplored
+	}
+
+	if options.Logger != nil {
+		options.Logger.Debug("search failed",
+			"nodesExplored", result.NodesExplored, "duration", result.ComputationTime, "error", result.Err)
+	}
+
+	return result
+}
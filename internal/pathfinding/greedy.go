@@ -64,6 +64,10 @@ func FindPathGreedy(start, goal Point, world World) []Point {
 }
 
 func FindPathGreedyWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchGreedy)
+}
+
+func searchGreedy(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	openSet := &PriorityQueue{}
@@ -88,10 +92,17 @@ func FindPathGreedyWithOptions(start, goal Point, world World, options Pathfindi
 	breakPoints := make(map[Point]bool)
 	placePoints := make(map[Point]bool)
 
+	monitor := newSearchMonitor(options)
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 		nodesExplored++
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			break
+		}
+
 		if visited[current.Position] {
 			continue
 		}
@@ -72,6 +72,10 @@ func idaSearchDFS(current Point, g float64, bound float64, goal Point, world Wor
 }
 
 func FindPathIDAWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchIDA)
+}
+
+func searchIDA(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	maxIterations := options.MaxIterations
@@ -90,9 +94,13 @@ func FindPathIDAWithOptions(start, goal Point, world World, options PathfindingO
 	placePoints := make(map[Point]bool)
 
 	var finalPath []Point
+	var limitErr error
+
+	monitor := newSearchMonitor(options)
 
 	for iterations < maxIterations {
 		iterations++
+		monitor.setBound(bound, iterations)
 
 		visited := make(map[Point]bool)
 		pathStack := []Point{start}
@@ -102,7 +110,7 @@ func FindPathIDAWithOptions(start, goal Point, world World, options PathfindingO
 
 		result, newBound, explored, manipulationPoints :=
 			idaSearchWithOptions(start, 0, bound, goal, world, options, visited,
-				pathStack, gScores, parents, breakPoints, placePoints)
+				pathStack, gScores, parents, breakPoints, placePoints, monitor)
 
 		nodesExplored += explored
 
@@ -119,7 +127,12 @@ func FindPathIDAWithOptions(start, goal Point, world World, options PathfindingO
 			break
 		}
 
-		if newBound == math.Inf(1) || iterations >= maxIterations {
+		if newBound == math.Inf(1) || monitor.stopped() {
+			break
+		}
+
+		if iterations >= maxIterations {
+			limitErr = &SearchError{Kind: ErrIterationLimit, Limit: "maxIterations", LimitValue: maxIterations}
 			break
 		}
 
@@ -132,6 +145,7 @@ func FindPathIDAWithOptions(start, goal Point, world World, options PathfindingO
 			NodesExplored:   nodesExplored,
 			ComputationTime: time.Since(startTime),
 			Iterations:      iterations,
+			Err:             limitErr,
 		}
 	}
 
@@ -193,9 +207,17 @@ func idaSearchWithOptions(
 	gScores map[Point]float64,
 	parents map[Point]Point,
 	breakPoints, placePoints map[Point]bool,
+	monitor *searchMonitor,
 ) (bool, float64, int, manipulationPoints) {
 	f := g + Heuristic(current, goal, options)
 
+	if !monitor.expand(current, f, len(pathStack), stackFrontier(pathStack)) {
+		return false, math.Inf(1), 1, manipulationPoints{
+			breaks: make(map[Point]bool),
+			places: make(map[Point]bool),
+		}
+	}
+
 	if f > bound {
 		return false, f, 1, manipulationPoints{
 			breaks: make(map[Point]bool),
@@ -301,7 +323,7 @@ func idaSearchWithOptions(
 		found, newBound, explored, manipPoints := idaSearchWithOptions(
 			neighbor, newG, bound, goal, world, options, visited,
 			append(pathStack, neighbor), gScores, parents,
-			breakPoints, placePoints,
+			breakPoints, placePoints, monitor,
 		)
 
 		totalExplored += explored
@@ -313,7 +335,7 @@ func idaSearchWithOptions(
 			allManipulationPoints.places[p] = true
 		}
 
-		if found {
+		if found || monitor.stopped() {
 
 			for p := range localBreakPoints {
 				allManipulationPoints.breaks[p] = true
@@ -321,7 +343,7 @@ func idaSearchWithOptions(
 			for p := range localPlacePoints {
 				allManipulationPoints.places[p] = true
 			}
-			return true, bound, totalExplored, allManipulationPoints
+			return found, bound, totalExplored, allManipulationPoints
 		}
 
 		if newBound < minBound {
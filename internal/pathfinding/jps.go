@@ -62,11 +62,15 @@ func FindPathJPS(start, goal Point, world World) []Point {
 }
 
 func FindPathJPSWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchJPS)
+}
+
+func searchJPS(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	if options.AllowBreaking || options.AllowPlacing || options.AvoidWater {
 
-		return FindPathWithOptions(start, goal, world, options)
+		return searchAStar(start, goal, world, options)
 	}
 
 	openSet := &PriorityQueue{}
@@ -88,10 +92,17 @@ func FindPathJPSWithOptions(start, goal Point, world World, options PathfindingO
 
 	nodesExplored := 0
 
+	monitor := newSearchMonitor(options)
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 		nodesExplored++
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			break
+		}
+
 		if current.Position.X == goal.X && current.Position.Y == goal.Y && current.Position.Z == goal.Z {
 
 			path := []Point{}
@@ -1,6 +1,8 @@
 package pathfinding
 
 import (
+	"context"
+	"log/slog"
 	"math"
 	"time"
 )
@@ -12,7 +14,17 @@ type PathfindingOptions struct {
 	MinimiseHeight        bool
 	JumpPointOptimisation bool
 	MaxIterations         int
+	MaxNodes              int
+	MaxFrontier           int
 	HeuristicWeight       float64
+
+	Context               context.Context
+	Progress              func(SearchProgress)
+	ProgressInterval      int
+	ProgressFrontierLimit int
+	Logger                *slog.Logger
+
+	stats *searchStats
 }
 
 type PathfindingResult struct {
@@ -27,6 +39,7 @@ type PathfindingResult struct {
 	MaxMemoryUsed   int
 	Iterations      int
 	OptimalityRatio float64
+	Err             error
 }
 
 type World interface {
@@ -37,6 +50,10 @@ type World interface {
 }
 
 func FindPathWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchAStar)
+}
+
+func searchAStar(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	var result PathfindingResult
@@ -121,9 +138,10 @@ func findPathWithPlacing(start, goal Point, world World, options PathfindingOpti
 func findPathStandard(start, goal Point, world World, options PathfindingOptions) (
 	[]Point, int, int, int, float64) {
 
-	path := FindPath(start, goal, world)
+	monitor := newSearchMonitor(options)
+	path := aStarSearch(start, goal, world, monitor)
 
-	nodesExplored := 0
+	nodesExplored := monitor.nodes
 	waterCrossed := 0
 	verticalChange := 0
 	totalCost := 0.0
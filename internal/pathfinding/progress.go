@@ -0,0 +1,186 @@
+package pathfinding
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"time"
+)
+
+type SearchProgress struct {
+	NodesExplored int
+	FrontierSize  int
+	BestFScore    float64
+	Bound         float64
+	Iteration     int
+	Current       Point
+	Frontier      []Point
+	Elapsed       time.Duration
+}
+
+type searchStats struct {
+	peakFrontier int
+	limitErr     error
+}
+
+type searchMonitor struct {
+	ctx           context.Context
+	progress      func(SearchProgress)
+	interval      int
+	frontierLimit int
+	maxNodes      int
+	maxFrontier   int
+	stats         *searchStats
+
+	startTime  time.Time
+	nodes      int
+	bestFScore float64
+	bound      float64
+	iteration  int
+	cancelled  bool
+}
+
+func newSearchMonitor(options PathfindingOptions) *searchMonitor {
+	interval := options.ProgressInterval
+	if interval <= 0 {
+		interval = 100
+	}
+
+	return &searchMonitor{
+		ctx:           options.Context,
+		progress:      options.Progress,
+		interval:      interval,
+		frontierLimit: options.ProgressFrontierLimit,
+		maxNodes:      options.MaxNodes,
+		maxFrontier:   options.MaxFrontier,
+		stats:         options.stats,
+		startTime:     time.Now(),
+		bestFScore:    math.Inf(1),
+		bound:         math.NaN(),
+	}
+}
+
+func (m *searchMonitor) expand(current Point, fScore float64, frontierSize int, frontier func(limit int) []Point) bool {
+	if m == nil {
+		return true
+	}
+
+	m.nodes++
+
+	if m.stats != nil && frontierSize > m.stats.peakFrontier {
+		m.stats.peakFrontier = frontierSize
+	}
+
+	if fScore < m.bestFScore {
+		m.bestFScore = fScore
+	}
+
+	if m.maxNodes > 0 && m.nodes > m.maxNodes {
+		return m.exceed(&SearchError{Kind: ErrIterationLimit, Limit: "maxNodes", LimitValue: m.maxNodes})
+	}
+	if m.maxFrontier > 0 && frontierSize > m.maxFrontier {
+		return m.exceed(&SearchError{Kind: ErrMemoryLimit, Limit: "maxFrontier", LimitValue: m.maxFrontier})
+	}
+
+	if m.ctx != nil && m.nodes&63 == 0 && m.ctx.Err() != nil {
+		m.cancelled = true
+		return false
+	}
+
+	if m.progress != nil && m.nodes%m.interval == 0 {
+		m.report(current, frontierSize, frontier)
+	}
+
+	return true
+}
+
+func (m *searchMonitor) exceed(err *SearchError) bool {
+	if m.stats != nil && m.stats.limitErr == nil {
+		m.stats.limitErr = err
+	}
+
+	m.cancelled = true
+	return false
+}
+
+func (m *searchMonitor) setBound(bound float64, iteration int) {
+	if m == nil {
+		return
+	}
+
+	m.bound = bound
+	m.iteration = iteration
+	m.bestFScore = math.Inf(1)
+}
+
+func (m *searchMonitor) setIteration(iteration int) {
+	if m == nil {
+		return
+	}
+
+	m.iteration = iteration
+}
+
+func (m *searchMonitor) stopped() bool {
+	if m == nil {
+		return false
+	}
+
+	if !m.cancelled && m.ctx != nil && m.ctx.Err() != nil {
+		m.cancelled = true
+	}
+
+	return m.cancelled
+}
+
+func (m *searchMonitor) report(current Point, frontierSize int, frontier func(limit int) []Point) {
+	event := SearchProgress{
+		NodesExplored: m.nodes,
+		FrontierSize:  frontierSize,
+		BestFScore:    m.bestFScore,
+		Bound:         m.bound,
+		Iteration:     m.iteration,
+		Current:       current,
+		Elapsed:       time.Since(m.startTime),
+	}
+
+	if m.frontierLimit > 0 && frontier != nil {
+		event.Frontier = frontier(m.frontierLimit)
+	}
+
+	m.progress(event)
+}
+
+func queueFrontier(pq *PriorityQueue) func(limit int) []Point {
+	return func(limit int) []Point {
+		points := make([]Point, 0, min(limit, pq.Len()))
+		for _, node := range *pq {
+			if len(points) >= limit {
+				break
+			}
+			points = append(points, node.Position)
+		}
+		return points
+	}
+}
+
+func listFrontier(queues ...*list.List) func(limit int) []Point {
+	return func(limit int) []Point {
+		points := []Point{}
+		for _, queue := range queues {
+			for e := queue.Front(); e != nil && len(points) < limit; e = e.Next() {
+				points = append(points, e.Value.(Point))
+			}
+		}
+		return points
+	}
+}
+
+func stackFrontier(stack []Point) func(limit int) []Point {
+	return func(limit int) []Point {
+		if len(stack) > limit {
+			stack = stack[len(stack)-limit:]
+		}
+		return append([]Point(nil), stack...)
+	}
+}
@@ -92,6 +92,10 @@ func FindPathThetaStar(start, goal Point, world World) []Point {
 }
 
 func FindPathThetaStarWithOptions(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
+	return runSearch(start, goal, world, options, searchThetaStar)
+}
+
+func searchThetaStar(start, goal Point, world World, options PathfindingOptions) PathfindingResult {
 	startTime := time.Now()
 
 	openSet := &PriorityQueue{}
@@ -117,10 +121,17 @@ func FindPathThetaStarWithOptions(start, goal Point, world World, options Pathfi
 	breakPoints := make(map[Point]bool)
 	placePoints := make(map[Point]bool)
 
+	monitor := newSearchMonitor(options)
+	frontier := queueFrontier(openSet)
+
 	for openSet.Len() > 0 {
 		current := heap.Pop(openSet).(*Node)
 		nodesExplored++
 
+		if !monitor.expand(current.Position, current.FScore, openSet.Len(), frontier) {
+			break
+		}
+
 		if current.Position.X == goal.X && current.Position.Y == goal.Y && current.Position.Z == goal.Z {
 			path := []Point{}
 			waterCrossed := 0
@@ -0,0 +1,207 @@
+package planner
+
+import (
+	"math"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type ActionType string
+
+const (
+	Move        ActionType = "move"
+	Jump        ActionType = "jump"
+	Fall        ActionType = "fall"
+	Break       ActionType = "break"
+	Place       ActionType = "place"
+	Swim        ActionType = "swim"
+	Climb       ActionType = "climb"
+	OpenDoor    ActionType = "open_door"
+	Unsupported ActionType = "unsupported"
+)
+
+type Action struct {
+	Type     ActionType
+	From     pathfinding.Point
+	To       pathfinding.Point
+	Block    string
+	Face     string
+	Duration time.Duration
+}
+
+const (
+	PlaceBlock = "stone"
+
+	walkTime     = 250 * time.Millisecond
+	verticalTime = 200 * time.Millisecond
+	swimTime     = 500 * time.Millisecond
+	placeTime    = 500 * time.Millisecond
+	doorTime     = 250 * time.Millisecond
+	breakTime    = time.Second
+)
+
+var supports = []struct {
+	face   string
+	offset pathfinding.Point
+}{
+	{"up", pathfinding.Point{Y: -1}},
+	{"east", pathfinding.Point{X: -1}},
+	{"west", pathfinding.Point{X: 1}},
+	{"south", pathfinding.Point{Z: -1}},
+	{"north", pathfinding.Point{Z: 1}},
+	{"down", pathfinding.Point{Y: 1}},
+}
+
+var breakTimes = map[string]time.Duration{
+	"grass":  900 * time.Millisecond,
+	"sand":   750 * time.Millisecond,
+	"stone":  1150 * time.Millisecond,
+	"ice":    750 * time.Millisecond,
+	"wood":   3 * time.Second,
+	"ladder": 600 * time.Millisecond,
+	"door":   3 * time.Second,
+}
+
+func BreakTime(blockType string) time.Duration {
+	if d, ok := breakTimes[blockType]; ok {
+		return d
+	}
+	return breakTime
+}
+
+func Plan(result pathfinding.PathfindingResult, world pathfinding.World) []Action {
+	if len(result.Path) < 2 {
+		return []Action{}
+	}
+
+	placed := make(map[pathfinding.Point]bool, len(result.BlocksPlaced))
+	for _, p := range result.BlocksPlaced {
+		placed[p] = true
+	}
+
+	changed := make(map[pathfinding.Point]string)
+	solid := func(p pathfinding.Point) bool {
+		if block, ok := changed[p]; ok {
+			return block != "air"
+		}
+		return !world.IsWalkable(p) && world.GetBlockType(p) != "unknown"
+	}
+
+	actions := make([]Action, 0, len(result.Path))
+	for i := 1; i < len(result.Path); i++ {
+		from, to := result.Path[i-1], result.Path[i]
+		target := world.GetBlockType(to)
+
+		switch {
+		case placed[to]:
+			block, face, needed := placement(from, to, solid)
+			switch {
+			case needed && face == "":
+				actions = append(actions, Action{
+					Type: Unsupported, From: from, To: block, Block: PlaceBlock,
+				})
+			case needed:
+				actions = append(actions, Action{
+					Type: Place, From: from, To: block,
+					Block: PlaceBlock, Face: face, Duration: placeTime,
+				})
+				changed[block] = PlaceBlock
+			}
+		case !world.IsWalkable(to) && world.CanBreak(to):
+			actions = append(actions, Action{
+				Type: Break, From: from, To: to,
+				Block: target, Duration: BreakTime(target),
+			})
+			changed[to] = "air"
+			target = "air"
+		case target == "door":
+			actions = append(actions, Action{
+				Type: OpenDoor, From: from, To: to,
+				Block: target, Duration: doorTime,
+			})
+		}
+
+		actions = append(actions, movement(from, to, world.GetBlockType(from), target))
+	}
+
+	return actions
+}
+
+func placement(from, to pathfinding.Point, solid func(pathfinding.Point) bool) (pathfinding.Point, string, bool) {
+	dx, dz := sign(to.X-from.X), sign(to.Z-from.Z)
+	steps := max(abs(to.X-from.X), abs(to.Z-from.Z))
+
+	var floors []pathfinding.Point
+	for s := 1; s <= steps; s++ {
+		floors = append(floors, pathfinding.Point{X: from.X + dx*s, Y: to.Y - 1, Z: from.Z + dz*s})
+	}
+	if steps == 0 {
+		floors = append(floors, pathfinding.Point{X: to.X, Y: to.Y - 1, Z: to.Z})
+	}
+
+	var unsupported pathfinding.Point
+	needed := false
+	for _, block := range floors {
+		if solid(block) {
+			continue
+		}
+		for _, support := range supports {
+			against := pathfinding.Point{X: block.X + support.offset.X, Y: block.Y + support.offset.Y, Z: block.Z + support.offset.Z}
+			if solid(against) {
+				return block, support.face, true
+			}
+		}
+		if !needed {
+			unsupported, needed = block, true
+		}
+	}
+	return unsupported, "", needed
+}
+
+func movement(from, to pathfinding.Point, source, target string) Action {
+	dx, dy, dz := float64(to.X-from.X), to.Y-from.Y, float64(to.Z-from.Z)
+	horizontal := time.Duration(math.Hypot(dx, dz) * float64(walkTime))
+	vertical := time.Duration(abs(dy)) * verticalTime
+
+	action := Action{From: from, To: to, Duration: horizontal + vertical}
+	switch {
+	case target == "water":
+		action.Type = Swim
+		action.Duration = horizontal + vertical + swimTime
+	case dy != 0 && (source == "ladder" || target == "ladder"):
+		action.Type = Climb
+	case dy > 0:
+		action.Type = Jump
+	case dy < 0:
+		action.Type = Fall
+	default:
+		action.Type = Move
+	}
+	return action
+}
+
+func Total(actions []Action) time.Duration {
+	var total time.Duration
+	for _, a := range actions {
+		total += a.Duration
+	}
+	return total
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	clients map[string]*bucket
+}
+
+func NewLimiter(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		clients: make(map[string]*bucket),
+	}
+}
+
+func (l *Limiter) Enabled() bool {
+	return l != nil && l.rate > 0
+}
+
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	if !l.Enabled() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.clients[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.clients[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / l.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+func (l *Limiter) Prune(now time.Time) int {
+	if !l.Enabled() {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+
+	pruned := 0
+	for key, b := range l.clients {
+		if now.Sub(b.last) > refill {
+			delete(l.clients, key)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+func (l *Limiter) Janitor(interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				l.Prune(now)
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/WillKirkmanM/paritone/internal/bench"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "|", `\|`, "`", "\\`")
+
+var sharedFuncs = map[string]interface{}{
+	"num": func(v float64) string {
+		return formatNumber(v)
+	},
+	"solved": func(found int, v float64) string {
+		if found == 0 {
+			return "-"
+		}
+		return formatNumber(v)
+	},
+	"ratio": func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.3f", *v)
+	},
+	"delta": func(c bench.Change) string {
+		if c.Metric == "found" {
+			if c.Current == 0 {
+				return "path lost"
+			}
+			return "path found"
+		}
+		return fmt.Sprintf("%+.1f%%", c.Delta*100)
+	},
+	"verdict": func(c bench.Change) string {
+		switch {
+		case c.Regression:
+			return "regression"
+		case c.Improvement:
+			return "improvement"
+		}
+		return "changed"
+	},
+}
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("report.html.tmpl").
+	Funcs(sharedFuncs).
+	Funcs(htmltemplate.FuncMap{
+		"svg": func(s string) htmltemplate.HTML {
+			return htmltemplate.HTML(s)
+		},
+	}).
+	ParseFS(templates, "templates/report.html.tmpl"))
+
+func WriteHTML(w io.Writer, r Report) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+func WriteMarkdown(w io.Writer, r Report, images map[string]string) error {
+	t, err := template.New("report.md.tmpl").
+		Funcs(sharedFuncs).
+		Funcs(template.FuncMap{
+			"md": markdownEscaper.Replace,
+			"bold": func(best bool, s string) string {
+				if best {
+					return "**" + s + "**"
+				}
+				return s
+			},
+			"image": func(name string) string {
+				return images[name]
+			},
+		}).
+		ParseFS(templates, "templates/report.md.tmpl")
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, r)
+}
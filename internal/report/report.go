@@ -0,0 +1,411 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/bench"
+)
+
+type Chart struct {
+	Name  string
+	Title string
+	SVG   string
+}
+
+type Row struct {
+	Algorithm     string
+	Label         string
+	Optimal       bool
+	Cases         int
+	Found         int
+	TimeP50       float64
+	TimeP90       float64
+	NodesExplored float64
+	MaxMemoryUsed float64
+	AllocsPerRun  float64
+	PathLength    float64
+	TotalCost     float64
+	Ratio         *float64
+	Errors        []string
+
+	Fastest     bool
+	FewestNodes bool
+	Cheapest    bool
+}
+
+type Section struct {
+	Scenario string
+	Cases    []string
+	Rows     []Row
+	Chart    Chart
+}
+
+type Algorithm struct {
+	Algorithm    string
+	Label        string
+	Optimal      bool
+	Color        string
+	Cases        int
+	Found        int
+	TimeGeoMean  float64
+	NodesMean    float64
+	MemoryMean   float64
+	AllocsMean   float64
+	RatioMean    *float64
+	FastestWins  int
+	FewestWins   int
+	CheapestWins int
+	Strengths    []string
+}
+
+type Report struct {
+	Title       string
+	Generated   time.Time
+	Environment bench.Environment
+	Warmup      int
+	Repetitions int
+	Cases       int
+	Algorithms  []Algorithm
+	Sections    []Section
+	Charts      []Chart
+	Comparison  *bench.Comparison
+}
+
+func (r Report) AllCharts() []Chart {
+	charts := append([]Chart(nil), r.Charts...)
+	for _, s := range r.Sections {
+		charts = append(charts, s.Chart)
+	}
+	return charts
+}
+
+func Build(title string, results bench.Report, comparison *bench.Comparison) Report {
+	report := Report{
+		Title:       title,
+		Generated:   time.Now().UTC(),
+		Environment: results.Environment,
+		Warmup:      results.Warmup,
+		Repetitions: results.Repetitions,
+		Comparison:  comparison,
+	}
+
+	var scenarioOrder, algorithmOrder, caseOrder []string
+	byScenario := make(map[string][]bench.Result)
+	byAlgorithm := make(map[string][]bench.Result)
+	byCase := make(map[string][]bench.Result)
+	for _, r := range results.Results {
+		if _, ok := byScenario[r.Scenario]; !ok {
+			scenarioOrder = append(scenarioOrder, r.Scenario)
+		}
+		if _, ok := byAlgorithm[r.Algorithm]; !ok {
+			algorithmOrder = append(algorithmOrder, r.Algorithm)
+		}
+		if _, ok := byCase[r.Case]; !ok {
+			caseOrder = append(caseOrder, r.Case)
+		}
+		byScenario[r.Scenario] = append(byScenario[r.Scenario], r)
+		byAlgorithm[r.Algorithm] = append(byAlgorithm[r.Algorithm], r)
+		byCase[r.Case] = append(byCase[r.Case], r)
+	}
+	report.Cases = len(caseOrder)
+
+	colors := make(map[string]string, len(algorithmOrder))
+	for i, name := range algorithmOrder {
+		colors[name] = palette[i%len(palette)]
+	}
+
+	ratios := costRatios(byCase)
+
+	fastest := make(map[string]int)
+	fewest := make(map[string]int)
+	cheapest := make(map[string]int)
+	for _, name := range caseOrder {
+		for _, winner := range winners(byCase[name], func(r bench.Result) float64 { return r.TimeP50 }) {
+			fastest[winner]++
+		}
+		for _, winner := range winners(byCase[name], func(r bench.Result) float64 { return float64(r.NodesExplored) }) {
+			fewest[winner]++
+		}
+		for _, winner := range winners(byCase[name], func(r bench.Result) float64 { return ratios[r.Key()] }) {
+			cheapest[winner]++
+		}
+	}
+
+	for _, name := range algorithmOrder {
+		runs := byAlgorithm[name]
+		a := Algorithm{
+			Algorithm:    name,
+			Label:        runs[0].Label,
+			Optimal:      runs[0].Optimal,
+			Color:        colors[name],
+			Cases:        len(runs),
+			FastestWins:  fastest[name],
+			FewestWins:   fewest[name],
+			CheapestWins: cheapest[name],
+		}
+
+		var times, nodes, memory, allocs, costs []float64
+		for _, r := range runs {
+			if !r.Found {
+				continue
+			}
+			a.Found++
+			times = append(times, r.TimeP50)
+			nodes = append(nodes, float64(r.NodesExplored))
+			memory = append(memory, float64(r.MaxMemoryUsed))
+			allocs = append(allocs, float64(r.AllocsPerRun))
+			costs = append(costs, ratios[r.Key()])
+		}
+		a.TimeGeoMean = geometricMean(times)
+		a.NodesMean = bench.Mean(nodes)
+		a.MemoryMean = bench.Mean(memory)
+		a.AllocsMean = bench.Mean(allocs)
+		if len(costs) > 0 {
+			mean := bench.Mean(costs)
+			a.RatioMean = &mean
+		}
+
+		report.Algorithms = append(report.Algorithms, a)
+	}
+	addStrengths(report.Algorithms, report.Cases)
+
+	var timeBars, nodeBars []bar
+	var overall []point
+	for _, a := range report.Algorithms {
+		timeBars = append(timeBars, bar{Label: a.Label, Value: a.TimeGeoMean, Color: a.Color})
+		nodeBars = append(nodeBars, bar{Label: a.Label, Value: a.NodesMean, Color: a.Color})
+	}
+	for _, r := range results.Results {
+		if r.Found {
+			overall = append(overall, point{
+				Series: r.Label, Label: r.Label + " on " + r.Case,
+				X: float64(r.NodesExplored), Y: ratios[r.Key()], Color: colors[r.Algorithm],
+			})
+		}
+	}
+	report.Charts = []Chart{
+		{Name: "time", Title: "Search time (geometric mean of p50, µs)", SVG: logBarChart("Search time (geometric mean of p50)", "µs", timeBars)},
+		{Name: "nodes", Title: "Nodes explored (mean)", SVG: logBarChart("Nodes explored (mean)", "nodes", nodeBars)},
+		{Name: "scatter", Title: "Nodes explored against optimality ratio", SVG: scatterPlot("Nodes explored against optimality ratio", "nodes explored (log scale)", "cost / cheapest path found", overall)},
+	}
+
+	for i, scenario := range scenarioOrder {
+		report.Sections = append(report.Sections, buildSection(i, scenario, byScenario[scenario], algorithmOrder, colors, ratios))
+	}
+
+	return report
+}
+
+func buildSection(index int, scenario string, results []bench.Result, algorithmOrder []string, colors map[string]string, ratios map[string]float64) Section {
+	section := Section{Scenario: scenario}
+
+	byAlgorithm := make(map[string][]bench.Result)
+	seenCase := make(map[string]bool)
+	var points []point
+	for _, r := range results {
+		byAlgorithm[r.Algorithm] = append(byAlgorithm[r.Algorithm], r)
+		if !seenCase[r.Case] {
+			seenCase[r.Case] = true
+			section.Cases = append(section.Cases, r.Case)
+		}
+		if r.Found {
+			points = append(points, point{
+				Series: r.Label, Label: r.Label + " on " + r.Case,
+				X: float64(r.NodesExplored), Y: ratios[r.Key()], Color: colors[r.Algorithm],
+			})
+		}
+	}
+
+	for _, name := range algorithmOrder {
+		runs := byAlgorithm[name]
+		if len(runs) == 0 {
+			continue
+		}
+
+		row := Row{Algorithm: name, Label: runs[0].Label, Optimal: runs[0].Optimal, Cases: len(runs)}
+		var p50, p90, nodes, memory, allocs, length, cost, ratio []float64
+		for _, r := range runs {
+			if !r.Found {
+				row.Errors = append(row.Errors, r.Error)
+				continue
+			}
+			row.Found++
+			p50 = append(p50, r.TimeP50)
+			p90 = append(p90, r.TimeP90)
+			nodes = append(nodes, float64(r.NodesExplored))
+			memory = append(memory, float64(r.MaxMemoryUsed))
+			allocs = append(allocs, float64(r.AllocsPerRun))
+			length = append(length, float64(r.PathLength))
+			cost = append(cost, r.TotalCost)
+			ratio = append(ratio, ratios[r.Key()])
+		}
+		row.TimeP50 = bench.Mean(p50)
+		row.TimeP90 = bench.Mean(p90)
+		row.NodesExplored = bench.Mean(nodes)
+		row.MaxMemoryUsed = bench.Mean(memory)
+		row.AllocsPerRun = bench.Mean(allocs)
+		row.PathLength = bench.Mean(length)
+		row.TotalCost = bench.Mean(cost)
+		if len(ratio) > 0 {
+			mean := bench.Mean(ratio)
+			row.Ratio = &mean
+		}
+		section.Rows = append(section.Rows, row)
+	}
+
+	markBest(section.Rows, func(r Row) float64 { return r.TimeP50 }, func(r *Row) { r.Fastest = true })
+	markBest(section.Rows, func(r Row) float64 { return r.NodesExplored }, func(r *Row) { r.FewestNodes = true })
+	markBest(section.Rows, func(r Row) float64 { return *r.Ratio }, func(r *Row) { r.Cheapest = true })
+
+	title := "Nodes explored against cost ratio: " + scenario
+	if len(section.Cases) > 1 {
+		title = fmt.Sprintf("%s (%d cases)", title, len(section.Cases))
+	}
+	section.Chart = Chart{
+		Name:  "scatter-" + slug(scenario, index),
+		Title: title,
+		SVG:   scatterPlot(title, "nodes explored (log scale)", "cost / cheapest path found", points),
+	}
+
+	return section
+}
+
+func costRatios(byCase map[string][]bench.Result) map[string]float64 {
+	ratios := make(map[string]float64)
+	for _, results := range byCase {
+		best := math.Inf(1)
+		for _, r := range results {
+			if r.Found {
+				best = math.Min(best, r.TotalCost)
+			}
+		}
+		for _, r := range results {
+			if !r.Found {
+				continue
+			}
+			ratios[r.Key()] = 1
+			if best > 0 {
+				ratios[r.Key()] = r.TotalCost / best
+			}
+		}
+	}
+	return ratios
+}
+
+func winners(results []bench.Result, metric func(bench.Result) float64) []string {
+	best := math.Inf(1)
+	for _, r := range results {
+		if r.Found {
+			best = math.Min(best, metric(r))
+		}
+	}
+
+	var names []string
+	for _, r := range results {
+		if r.Found && metric(r) <= best*(1+1e-9) {
+			names = append(names, r.Algorithm)
+		}
+	}
+	return names
+}
+
+func markBest(rows []Row, metric func(Row) float64, mark func(*Row)) {
+	best := math.Inf(1)
+	for _, r := range rows {
+		if r.Found == r.Cases {
+			best = math.Min(best, metric(r))
+		}
+	}
+	for i := range rows {
+		if rows[i].Found == rows[i].Cases && metric(rows[i]) <= best*(1+1e-9) {
+			mark(&rows[i])
+		}
+	}
+}
+
+func addStrengths(algorithms []Algorithm, cases int) {
+	leaders := func(metric func(Algorithm) float64) map[string]bool {
+		best := math.Inf(1)
+		for _, a := range algorithms {
+			if a.Found > 0 {
+				best = math.Min(best, metric(a))
+			}
+		}
+		set := make(map[string]bool)
+		for _, a := range algorithms {
+			if a.Found > 0 && metric(a) <= best*(1+1e-9) {
+				set[a.Algorithm] = true
+			}
+		}
+		return set
+	}
+
+	fastest := leaders(func(a Algorithm) float64 { return a.TimeGeoMean })
+	fewest := leaders(func(a Algorithm) float64 { return a.NodesMean })
+	leanest := leaders(func(a Algorithm) float64 { return a.MemoryMean })
+	allocs := leaders(func(a Algorithm) float64 { return a.AllocsMean })
+
+	for i := range algorithms {
+		a := &algorithms[i]
+		add := func(format string, args ...interface{}) {
+			a.Strengths = append(a.Strengths, fmt.Sprintf(format, args...))
+		}
+
+		if fastest[a.Algorithm] {
+			add("Fastest overall (%s µs geometric mean)", formatNumber(a.TimeGeoMean))
+		}
+		if fewest[a.Algorithm] {
+			add("Explores the fewest nodes (%s on average)", formatNumber(a.NodesMean))
+		}
+		if leanest[a.Algorithm] {
+			add("Smallest frontier (%s nodes on average)", formatNumber(a.MemoryMean))
+		}
+		if allocs[a.Algorithm] {
+			add("Fewest allocations (%s per search)", formatNumber(a.AllocsMean))
+		}
+		if a.Found == cases && cases > 0 {
+			add("Found a path in all %d cases", cases)
+		}
+		if a.CheapestWins > 0 && a.CheapestWins >= a.Found && a.Found > 0 {
+			add("Cheapest path in every case it solved")
+		} else if a.CheapestWins > 0 {
+			add("Cheapest path in %d of %d cases", a.CheapestWins, cases)
+		}
+		if a.FastestWins > 0 && !fastest[a.Algorithm] {
+			add("Fastest in %d of %d cases", a.FastestWins, cases)
+		}
+		if a.FewestWins > 0 && !fewest[a.Algorithm] {
+			add("Fewest nodes in %d of %d cases", a.FewestWins, cases)
+		}
+	}
+}
+
+func slug(name string, index int) string {
+	s := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, name)
+	if strings.Trim(s, "-") == "" {
+		return fmt.Sprint(index + 1)
+	}
+	return s
+}
+
+func geometricMean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += math.Log(math.Max(v, 1e-9))
+	}
+	return math.Exp(sum / float64(len(values)))
+}
@@ -0,0 +1,208 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+var palette = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd",
+	"#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf",
+}
+
+type bar struct {
+	Label string
+	Value float64
+	Color string
+}
+
+type point struct {
+	Series string
+	Label  string
+	X      float64
+	Y      float64
+	Color  string
+}
+
+func svgOpen(b *strings.Builder, width, height int, title string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12" role="img">`, width, height, width, height)
+	fmt.Fprintf(b, `<title>%s</title><rect width="100%%" height="100%%" fill="#fff"/>`, html.EscapeString(title))
+	fmt.Fprintf(b, `<text x="%d" y="20" text-anchor="middle" font-size="14" font-weight="bold">%s</text>`, width/2, html.EscapeString(title))
+}
+
+func logBarChart(title, unit string, bars []bar) string {
+	const (
+		left, right = 150, 110
+		top, rowH   = 34, 24
+		width       = 720
+	)
+	height := top + rowH*len(bars) + 30
+
+	var b strings.Builder
+	svgOpen(&b, width, height, title)
+
+	lo, hi := math.Inf(1), 0.0
+	for _, bar := range bars {
+		if bar.Value > 0 {
+			lo = math.Min(lo, bar.Value)
+			hi = math.Max(hi, bar.Value)
+		}
+	}
+	if hi == 0 {
+		b.WriteString(`</svg>`)
+		return b.String()
+	}
+
+	floor := math.Pow(10, math.Floor(math.Log10(lo)))
+	ceiling := math.Pow(10, math.Ceil(math.Log10(hi)))
+	if ceiling <= floor {
+		ceiling = floor * 10
+	}
+	span := float64(width - left - right)
+	scale := func(v float64) float64 {
+		return span * math.Log10(v/floor) / math.Log10(ceiling/floor)
+	}
+
+	for decade := floor; decade <= ceiling*1.0001; decade *= 10 {
+		x := float64(left) + scale(decade)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#ddd"/>`, x, top-4, x, height-26)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" fill="#666">%s</text>`, x, height-12, formatNumber(decade))
+	}
+
+	for i, bar := range bars {
+		y := top + i*rowH
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle">%s</text>`, left-8, y+rowH/2, html.EscapeString(bar.Label))
+		if bar.Value <= 0 {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" dominant-baseline="middle" fill="#999">no data</text>`, left+4, y+rowH/2)
+			continue
+		}
+		w := math.Max(scale(bar.Value), 2)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"><title>%s: %s %s</title></rect>`,
+			left, y+3, w, rowH-6, bar.Color, html.EscapeString(bar.Label), formatNumber(bar.Value), html.EscapeString(unit))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" dominant-baseline="middle">%s %s</text>`, float64(left)+w+6, y+rowH/2, formatNumber(bar.Value), html.EscapeString(unit))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+func scatterPlot(title, xLabel, yLabel string, points []point) string {
+	const (
+		left, right = 70, 170
+		top, bottom = 34, 50
+		width       = 720
+		height      = 400
+	)
+
+	var b strings.Builder
+	svgOpen(&b, width, height, title)
+
+	var xs, ys []float64
+	for _, p := range points {
+		if p.X > 0 {
+			xs = append(xs, p.X)
+			ys = append(ys, p.Y)
+		}
+	}
+	if len(xs) == 0 {
+		b.WriteString(`</svg>`)
+		return b.String()
+	}
+
+	xLo := math.Pow(10, math.Floor(math.Log10(minOf(xs))))
+	xHi := math.Pow(10, math.Ceil(math.Log10(maxOf(xs))))
+	if xHi <= xLo {
+		xHi = xLo * 10
+	}
+	yLo, yHi := minOf(ys), maxOf(ys)
+	pad := (yHi - yLo) * 0.08
+	if pad == 0 {
+		pad = math.Max(math.Abs(yLo)*0.1, 1)
+	}
+	yLo, yHi = yLo-pad, yHi+pad
+
+	plotW, plotH := float64(width-left-right), float64(height-top-bottom)
+	sx := func(v float64) float64 {
+		return float64(left) + plotW*math.Log10(v/xLo)/math.Log10(xHi/xLo)
+	}
+	sy := func(v float64) float64 {
+		return float64(top) + plotH*(1-(v-yLo)/(yHi-yLo))
+	}
+
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.0f" height="%.0f" fill="none" stroke="#999"/>`, left, top, plotW, plotH)
+	for decade := xLo; decade <= xHi*1.0001; decade *= 10 {
+		x := sx(decade)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#eee"/>`, x, top, x, float64(top)+plotH)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle" fill="#666">%s</text>`, x, float64(top)+plotH+16, formatNumber(decade))
+	}
+	for i := 0; i <= 4; i++ {
+		v := yLo + (yHi-yLo)*float64(i)/4
+		y := sy(v)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#eee"/>`, left, y, float64(left)+plotW, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle" fill="#666">%s</text>`, left-6, y, formatNumber(v))
+	}
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`, float64(left)+plotW/2, height-10, html.EscapeString(xLabel))
+	fmt.Fprintf(&b, `<text x="16" y="%.1f" text-anchor="middle" transform="rotate(-90 16 %.1f)">%s</text>`, float64(top)+plotH/2, float64(top)+plotH/2, html.EscapeString(yLabel))
+
+	var series []string
+	colors := make(map[string]string)
+	for _, p := range points {
+		if _, ok := colors[p.Series]; !ok {
+			series = append(series, p.Series)
+			colors[p.Series] = p.Color
+		}
+		if p.X <= 0 {
+			continue
+		}
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="5" fill="%s" fill-opacity="0.75" stroke="#333" stroke-width="0.5"><title>%s: %s nodes, cost %s</title></circle>`,
+			sx(p.X), sy(p.Y), p.Color, html.EscapeString(p.Label), formatNumber(p.X), formatNumber(p.Y))
+	}
+
+	for i, name := range series {
+		y := top + 8 + i*18
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="5" fill="%s"/>`, width-right+20, y, colors[name])
+		fmt.Fprintf(&b, `<text x="%d" y="%d" dominant-baseline="middle">%s</text>`, width-right+32, y, html.EscapeString(name))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+func formatNumber(v float64) string {
+	switch a := math.Abs(v); {
+	case a >= 1e6:
+		return trimZeros(fmt.Sprintf("%.1f", v/1e6)) + "M"
+	case a >= 1e4:
+		return trimZeros(fmt.Sprintf("%.1f", v/1e3)) + "k"
+	case a >= 100 || a == 0:
+		return fmt.Sprintf("%.0f", v)
+	case a >= 1:
+		return trimZeros(fmt.Sprintf("%.2f", v))
+	}
+	return trimZeros(fmt.Sprintf("%.3f", v))
+}
+
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
+
+func minOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func maxOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Max(m, v)
+	}
+	return m
+}
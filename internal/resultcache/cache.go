@@ -0,0 +1,145 @@
+package resultcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type Key struct {
+	World          uint64
+	Start          pathfinding.Point
+	Goal           pathfinding.Point
+	Algorithm      string
+	AllowBreaking  bool
+	AllowPlacing   bool
+	AvoidWater     bool
+	MinimiseHeight bool
+	MaxIterations  int
+	MaxNodes       int
+	MaxFrontier    int
+}
+
+func NewKey(worldHash uint64, algorithm string, start, goal pathfinding.Point, options pathfinding.PathfindingOptions) Key {
+	return Key{
+		World:          worldHash,
+		Start:          start,
+		Goal:           goal,
+		Algorithm:      algorithm,
+		AllowBreaking:  options.AllowBreaking,
+		AllowPlacing:   options.AllowPlacing,
+		AvoidWater:     options.AvoidWater,
+		MinimiseHeight: options.MinimiseHeight,
+		MaxIterations:  options.MaxIterations,
+		MaxNodes:       options.MaxNodes,
+		MaxFrontier:    options.MaxFrontier,
+	}
+}
+
+type entry struct {
+	key    Key
+	result pathfinding.PathfindingResult
+}
+
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[Key]*list.Element
+	byWorld  map[uint64]map[*list.Element]struct{}
+}
+
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[Key]*list.Element),
+		byWorld:  make(map[uint64]map[*list.Element]struct{}),
+	}
+}
+
+func (c *Cache) Enabled() bool {
+	return c != nil && c.capacity > 0
+}
+
+func (c *Cache) Get(key Key) (pathfinding.PathfindingResult, bool) {
+	if !c.Enabled() {
+		return pathfinding.PathfindingResult{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return pathfinding.PathfindingResult{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*entry).result, true
+}
+
+func (c *Cache) Add(key Key, result pathfinding.PathfindingResult) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).result = result
+		c.order.MoveToFront(element)
+		return
+	}
+
+	element := c.order.PushFront(&entry{key: key, result: result})
+	c.entries[key] = element
+	if c.byWorld[key.World] == nil {
+		c.byWorld[key.World] = make(map[*list.Element]struct{})
+	}
+	c.byWorld[key.World][element] = struct{}{}
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) InvalidateWorld(worldHash uint64) int {
+	if !c.Enabled() {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elements := c.byWorld[worldHash]
+	removed := len(elements)
+	for element := range elements {
+		c.remove(element)
+	}
+	return removed
+}
+
+func (c *Cache) Len() int {
+	if !c.Enabled() {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) remove(element *list.Element) {
+	e := element.Value.(*entry)
+	c.order.Remove(element)
+	delete(c.entries, e.key)
+
+	if elements := c.byWorld[e.key.World]; elements != nil {
+		delete(elements, element)
+		if len(elements) == 0 {
+			delete(c.byWorld, e.key.World)
+		}
+	}
+}
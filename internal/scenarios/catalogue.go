@@ -0,0 +1,267 @@
+package scenarios
+
+import (
+	"math"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+var catalogue = []Scenario{
+	{
+		ID:          "simple",
+		Name:        "Simple Obstacles",
+		Description: "Basic flat terrain with some stone obstacles",
+		Start:       pathfinding.Point{X: -15, Y: 1, Z: -15},
+		Goal:        pathfinding.Point{X: 15, Y: 1, Z: 15},
+		Build:       buildSimple,
+	},
+	{
+		ID:          "maze",
+		Name:        "Complex Maze",
+		Description: "Navigate through a complex maze with narrow passages",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: -18},
+		Goal:        pathfinding.Point{X: 18, Y: 1, Z: 18},
+		Build:       buildMaze,
+	},
+	{
+		ID:          "multilevel",
+		Name:        "Multi-Level Terrain",
+		Description: "Navigate across different elevations",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: -18},
+		Goal:        pathfinding.Point{X: 18, Y: 5, Z: 18},
+		Build:       buildMultilevel,
+	},
+	{
+		ID:          "mixedMaterials",
+		Name:        "Mixed Materials Challenge",
+		Description: "Navigate through different materials with varying traversal costs",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: 0},
+		Goal:        pathfinding.Point{X: 18, Y: 1, Z: 0},
+		Build:       buildMixedMaterials,
+	},
+	{
+		ID:          "islands",
+		Name:        "Islands Challenge",
+		Description: "Find your way across disconnected islands",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: -18},
+		Goal:        pathfinding.Point{X: 18, Y: 1, Z: 18},
+		Build:       buildIslands,
+	},
+	{
+		ID:          "algorithmComparison",
+		Name:        "Algorithm Comparison",
+		Description: "Scenario designed to showcase the differences between pathfinding algorithms",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: 0},
+		Goal:        pathfinding.Point{X: 18, Y: 1, Z: 0},
+		Build:       buildAlgorithmComparison,
+	},
+	{
+		ID:          "algorithmShowcase",
+		Name:        "Algorithm Showcase",
+		Description: "Complex scenario to showcase differences between pathfinding algorithms",
+		Start:       pathfinding.Point{X: -18, Y: 1, Z: 0},
+		Goal:        pathfinding.Point{X: 18, Y: 1, Z: 0},
+		Build:       buildAlgorithmShowcase,
+	},
+}
+
+func buildSimple(w *world.World) {
+	flatGround(w, 2, "grass")
+
+	fill(w, -5, 1, -5, 5, 1, 5, "stone")
+	fill(w, 10, 1, 10, 15, 1, 15, "water")
+}
+
+var mazePattern = []string{
+	"XXXXXXXXXXXXXXXXXXXXXXXXX",
+	"XS        X             X",
+	"XXXXXXXX  X  XXXXXXXXXXX",
+	"X         X  X           ",
+	"X  XXXXXXXX  X  XXXXXXXXX",
+	"X  X         X  X       X",
+	"X  X  XXXXXXXX  X  XXX  X",
+	"X  X  X         X  X X  X",
+	"X  X  X  XXXXXXXX  X X  X",
+	"X  X  X  X         X X  X",
+	"X  X  X  X  XXXXXXXX X  X",
+	"X  X  X  X  X        X  X",
+	"X  X  X  X  X  XXXXXX   X",
+	"X  X  X  X  X  X     XXXX",
+	"X  X  X  X  X  X  X     X",
+	"X  X  X  X  X  X  XXXX  X",
+	"X  X  X  X  X  X     X  X",
+	"X  X  X  X  X  XXXXX X  X",
+	"X  X  X  X  X        X  X",
+	"X  X  X  X  XXXXXXXXXX  X",
+	"X  X  X  X              X",
+	"X  X  X  XXXXXXXXXXXXXX X",
+	"X  X  X                 X",
+	"X  X  XXXXXXXXXXXXXXXXXXX",
+	"X  X                    G",
+	"XXXXXXXXXXXXXXXXXXXXXXXXXXE",
+}
+
+func buildMaze(w *world.World) {
+	flatGround(w, 2, "grass")
+
+	for z, row := range mazePattern {
+		for x, cell := range row {
+			if cell == 'X' {
+				set(w, x-12, 1, z-12, "stone")
+			}
+		}
+	}
+}
+
+func buildMultilevel(w *world.World) {
+	flatGround(w, 6, "grass")
+
+	fill(w, -20, 1, -20, -6, 1, -6, "air")
+
+	fill(w, -5, 1, -20, 20, 1, -6, "stone")
+	fill(w, -5, 2, -20, 20, 2, -6, "air")
+
+	fill(w, -20, 1, -5, -6, 2, 20, "stone")
+	fill(w, -20, 3, -5, -6, 3, 20, "air")
+
+	fill(w, -5, 1, -5, 4, 3, 4, "stone")
+	fill(w, -5, 4, -5, 4, 4, 4, "air")
+
+	fill(w, 5, 1, 5, 20, 4, 20, "stone")
+	fill(w, 5, 5, 5, 20, 5, 20, "air")
+
+	for i := 0; i < 5; i++ {
+		set(w, -5-i, 1, -15, "wood")
+		set(w, -10, 2, -5-i, "wood")
+		set(w, -5-i, 3, 0, "wood")
+		set(w, 0, 4, 5+i, "wood")
+	}
+}
+
+func buildMixedMaterials(w *world.World) {
+	flatGround(w, 2, "grass")
+
+	fill(w, -20, 1, -5, 20, 1, 5, "air")
+	fill(w, -15, 1, -3, -6, 1, 3, "sand")
+	fill(w, -5, 1, -4, 4, 1, 4, "water")
+	fill(w, 5, 1, -3, 14, 1, 3, "ice")
+
+	fill(w, -10, 1, -5, -10, 1, 5, "lava")
+	fill(w, 10, 1, -5, 10, 1, 5, "lava")
+
+	fill(w, -10, 1, -2, -10, 1, 2, "wood")
+	fill(w, 10, 1, -2, 10, 1, 2, "wood")
+
+	for x := -5; x <= 5; x++ {
+		if x%2 == 0 {
+			set(w, x, 1, -6, "stone")
+			set(w, x, 1, 6, "stone")
+		}
+	}
+}
+
+func buildIslands(w *world.World) {
+	flatGround(w, 2, "water")
+
+	islands := []struct{ x, z, radius int }{
+		{-18, -18, 3},
+		{-10, -12, 2},
+		{-5, -5, 2},
+		{0, 0, 3},
+		{8, 5, 2},
+		{12, 12, 2},
+		{18, 18, 3},
+	}
+
+	for _, island := range islands {
+		for x := -island.radius; x <= island.radius; x++ {
+			for z := -island.radius; z <= island.radius; z++ {
+				if x*x+z*z <= island.radius*island.radius {
+					set(w, island.x+x, 0, island.z+z, "grass")
+					set(w, island.x+x, 1, island.z+z, "air")
+				}
+			}
+		}
+	}
+
+	bridges := []struct{ x1, z1, x2, z2 int }{
+		{-15, -15, -10, -12},
+		{-8, -10, -5, -5},
+		{-3, -3, 0, 0},
+		{3, 3, 8, 5},
+		{10, 7, 12, 12},
+		{14, 14, 18, 18},
+	}
+
+	for _, bridge := range bridges {
+		dx := bridge.x2 - bridge.x1
+		dz := bridge.z2 - bridge.z1
+		steps := max(abs(dx), abs(dz))
+
+		for i := 0; i <= steps; i++ {
+			x := int(math.Floor(float64(bridge.x1) + float64(dx*i)/float64(steps)))
+			z := int(math.Floor(float64(bridge.z1) + float64(dz*i)/float64(steps)))
+			set(w, x, 0, z, "wood")
+			set(w, x, 1, z, "air")
+		}
+	}
+}
+
+func buildAlgorithmComparison(w *world.World) {
+	flatGround(w, 2, "grass")
+
+	fill(w, -18, 1, -5, 18, 1, 5, "air")
+	fill(w, -15, 1, 0, 15, 1, 0, "water")
+	fill(w, -15, 1, 3, 15, 1, 3, "ice")
+	fill(w, -15, 1, -3, 15, 1, -3, "ice")
+	fill(w, -15, 1, -3, -15, 1, 3, "ice")
+	fill(w, 15, 1, -3, 15, 1, 3, "ice")
+
+	for i := -10; i <= 10; i += 5 {
+		set(w, i, 1, 0, "stone")
+	}
+}
+
+func buildAlgorithmShowcase(w *world.World) {
+	flatGround(w, 4, "grass")
+
+	fill(w, -18, 1, -10, 18, 1, 10, "air")
+	fill(w, -15, 1, 0, 15, 1, 0, "water")
+	fill(w, -15, 1, 5, 15, 1, 5, "ice")
+	fill(w, -15, 1, -5, 15, 1, -5, "ice")
+	fill(w, -15, 1, -5, -15, 1, 5, "ice")
+	fill(w, 15, 1, -5, 15, 1, 5, "ice")
+
+	for i := -10; i <= 10; i += 5 {
+		set(w, i, 1, 0, "stone")
+	}
+
+	for x := -12; x <= 12; x += 3 {
+		fill(w, x, 1, 2, x, 1, 4, "stone")
+		fill(w, x+1, 1, -4, x+1, 1, -2, "stone")
+	}
+
+	for x := -8; x <= 8; x++ {
+		if x%4 != 0 {
+			set(w, x, 1, 2, "stone")
+			set(w, x, 1, -2, "stone")
+		}
+	}
+
+	for x := -5; x <= 5; x++ {
+		for z := -1; z <= 1; z++ {
+			set(w, x, 2, z, "wood")
+			if x%2 == 0 && z == 0 {
+				set(w, x, 3, z, "wood")
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
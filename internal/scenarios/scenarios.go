@@ -0,0 +1,67 @@
+package scenarios
+
+import (
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+type Scenario struct {
+	ID          string
+	Name        string
+	Description string
+	Start       pathfinding.Point
+	Goal        pathfinding.Point
+	Build       func(w *world.World)
+}
+
+func (s Scenario) World() *world.World {
+	w := world.NewWorld()
+	s.Build(w)
+
+	w.SetBlock(s.Start, world.MustBlock("air"))
+	w.SetBlock(s.Goal, world.MustBlock("air"))
+
+	return w
+}
+
+func All() []Scenario {
+	return append([]Scenario(nil), catalogue...)
+}
+
+func Get(id string) (Scenario, bool) {
+	for _, s := range catalogue {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Scenario{}, false
+}
+
+func IDs() []string {
+	ids := make([]string, len(catalogue))
+	for i, s := range catalogue {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func fill(w *world.World, x1, y1, z1, x2, y2, z2 int, blockType string) {
+	block := world.MustBlock(blockType)
+
+	for x := x1; x <= x2; x++ {
+		for y := y1; y <= y2; y++ {
+			for z := z1; z <= z2; z++ {
+				w.SetBlock(pathfinding.Point{X: x, Y: y, Z: z}, block)
+			}
+		}
+	}
+}
+
+func set(w *world.World, x, y, z int, blockType string) {
+	w.SetBlock(pathfinding.Point{X: x, Y: y, Z: z}, world.MustBlock(blockType))
+}
+
+func flatGround(w *world.World, height int, ground string) {
+	fill(w, -20, 0, -20, 20, height, 20, "air")
+	fill(w, -20, 0, -20, 20, 0, 20, ground)
+}
@@ -0,0 +1,248 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+var (
+	ErrNotFound  = errors.New("world not found")
+	ErrExists    = errors.New("world already exists")
+	ErrStoreFull = errors.New("world store is full")
+	ErrInvalidID = errors.New("invalid world id")
+	validID      = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+type Session struct {
+	ID        string
+	Source    string
+	CreatedAt time.Time
+
+	mu         sync.RWMutex
+	world      *world.World
+	lastAccess time.Time
+	accessMu   sync.Mutex
+}
+
+type Info struct {
+	ID         string            `json:"id"`
+	Source     string            `json:"source"`
+	Min        pathfinding.Point `json:"min"`
+	Max        pathfinding.Point `json:"max"`
+	Blocks     int               `json:"blocks"`
+	Hash       string            `json:"hash"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	LastAccess time.Time         `json:"lastAccess"`
+}
+
+func (s *Session) touch() {
+	s.accessMu.Lock()
+	s.lastAccess = time.Now()
+	s.accessMu.Unlock()
+}
+
+func (s *Session) idleSince() time.Time {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	return s.lastAccess
+}
+
+func (s *Session) Read(fn func(w *world.World)) {
+	s.touch()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.world)
+}
+
+func (s *Session) Write(fn func(w *world.World) error) error {
+	s.touch()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.world)
+}
+
+func (s *Session) Acquire() (*world.World, func()) {
+	s.touch()
+	s.mu.RLock()
+	return s.world, s.mu.RUnlock
+}
+
+func (s *Session) Replace(w *world.World) *world.World {
+	s.touch()
+	s.mu.Lock()
+	previous := s.world
+	s.world = w
+	s.mu.Unlock()
+	return previous
+}
+
+func (s *Session) Hash() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.world.Hash()
+}
+
+func (s *Session) Info() Info {
+	s.mu.RLock()
+	minPoint, maxPoint := s.world.Bounds()
+	blocks := len(s.world.Blocks)
+	hash := s.world.Hash()
+	s.mu.RUnlock()
+
+	return Info{
+		ID:         s.ID,
+		Source:     s.Source,
+		Min:        minPoint,
+		Max:        maxPoint,
+		Blocks:     blocks,
+		Hash:       fmt.Sprintf("%016x", hash),
+		CreatedAt:  s.CreatedAt,
+		LastAccess: s.idleSince(),
+	}
+}
+
+type Store struct {
+	mu          sync.Mutex
+	sessions    map[string]*Session
+	idleTimeout time.Duration
+	maxSessions int
+}
+
+func NewStore(idleTimeout time.Duration, maxSessions int) *Store {
+	return &Store{
+		sessions:    make(map[string]*Session),
+		idleTimeout: idleTimeout,
+		maxSessions: maxSessions,
+	}
+}
+
+func (st *Store) Create(id, source string, w *world.World) (*Session, error) {
+	if id == "" {
+		id = newID()
+	} else if !validID.MatchString(id) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if _, exists := st.sessions[id]; exists {
+		return nil, fmt.Errorf("%w: %q", ErrExists, id)
+	}
+	if st.maxSessions > 0 && len(st.sessions) >= st.maxSessions {
+		return nil, fmt.Errorf("%w: limit of %d worlds reached", ErrStoreFull, st.maxSessions)
+	}
+
+	now := time.Now()
+	s := &Session{
+		ID:         id,
+		Source:     source,
+		CreatedAt:  now,
+		world:      w,
+		lastAccess: now,
+	}
+	st.sessions[id] = s
+
+	return s, nil
+}
+
+func (st *Store) Get(id string) (*Session, error) {
+	st.mu.Lock()
+	s, exists := st.sessions[id]
+	st.mu.Unlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	s.touch()
+	return s, nil
+}
+
+func (st *Store) Delete(id string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if _, exists := st.sessions[id]; !exists {
+		return false
+	}
+	delete(st.sessions, id)
+	return true
+}
+
+func (st *Store) List() []Info {
+	st.mu.Lock()
+	sessions := make([]*Session, 0, len(st.sessions))
+	for _, s := range st.sessions {
+		sessions = append(sessions, s)
+	}
+	st.mu.Unlock()
+
+	infos := make([]Info, len(sessions))
+	for i, s := range sessions {
+		infos[i] = s.Info()
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+
+	return infos
+}
+
+func (st *Store) EvictIdle(now time.Time) []string {
+	if st.idleTimeout <= 0 {
+		return nil
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	var evicted []string
+	for id, s := range st.sessions {
+		if now.Sub(s.idleSince()) > st.idleTimeout {
+			delete(st.sessions, id)
+			evicted = append(evicted, id)
+		}
+	}
+
+	return evicted
+}
+
+func (st *Store) Janitor(interval time.Duration, onEvict func(ids []string)) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				if evicted := st.EvictIdle(now); len(evicted) > 0 && onEvict != nil {
+					onEvict(evicted)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("w%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
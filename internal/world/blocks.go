@@ -0,0 +1,38 @@
+package world
+
+import "sort"
+
+var blockTypes = map[string]Block{
+	"air":    {Type: "air", Walkable: true, Breakable: false, MoveCost: 1.0},
+	"grass":  {Type: "grass", Walkable: false, Breakable: true, MoveCost: 1.0},
+	"stone":  {Type: "stone", Walkable: false, Breakable: true, MoveCost: 5.0},
+	"water":  {Type: "water", Walkable: true, Breakable: false, MoveCost: 3.0},
+	"sand":   {Type: "sand", Walkable: true, Breakable: true, MoveCost: 1.5},
+	"lava":   {Type: "lava", Walkable: false, Breakable: true, MoveCost: 10.0},
+	"ice":    {Type: "ice", Walkable: true, Breakable: true, MoveCost: 0.7},
+	"wood":   {Type: "wood", Walkable: true, Breakable: false, MoveCost: 1.2},
+	"ladder": {Type: "ladder", Walkable: true, Breakable: true, MoveCost: 1.5},
+	"door":   {Type: "door", Walkable: true, Breakable: true, MoveCost: 1.2},
+}
+
+func NewBlock(blockType string) (Block, bool) {
+	block, exists := blockTypes[blockType]
+	return block, exists
+}
+
+func MustBlock(blockType string) Block {
+	block, exists := blockTypes[blockType]
+	if !exists {
+		panic("world: unknown block type " + blockType)
+	}
+	return block
+}
+
+func BlockTypes() []string {
+	types := make([]string, 0, len(blockTypes))
+	for t := range blockTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,154 @@
+package world
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type BlockData struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	Z    int    `json:"z"`
+	Type string `json:"type"`
+}
+
+func (w *World) BlockList() []BlockData {
+	blocks := make([]BlockData, 0, len(w.Blocks))
+
+	for p, block := range w.Blocks {
+		if block.Type == "air" {
+			continue
+		}
+		blocks = append(blocks, BlockData{X: p.X, Y: p.Y, Z: p.Z, Type: block.Type})
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		a, b := blocks[i], blocks[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Z < b.Z
+	})
+
+	return blocks
+}
+
+type Region struct {
+	Min  pathfinding.Point `json:"min"`
+	Max  pathfinding.Point `json:"max"`
+	Type string            `json:"type"`
+}
+
+func Volume(minPoint, maxPoint pathfinding.Point) (int, bool) {
+	if maxPoint.X < minPoint.X || maxPoint.Y < minPoint.Y || maxPoint.Z < minPoint.Z {
+		return 0, true
+	}
+
+	volume := 1
+	for _, span := range []int{maxPoint.X - minPoint.X + 1, maxPoint.Y - minPoint.Y + 1, maxPoint.Z - minPoint.Z + 1} {
+		if span <= 0 || volume > math.MaxInt/span {
+			return 0, false
+		}
+		volume *= span
+	}
+	return volume, true
+}
+
+func FromBlocks(minPoint, maxPoint pathfinding.Point, regions []Region, blocks []BlockData) (*World, error) {
+	volume, ok := Volume(minPoint, maxPoint)
+	if !ok {
+		return nil, fmt.Errorf("world bounds %v to %v are too large", minPoint, maxPoint)
+	}
+	if volume == 0 {
+		return nil, fmt.Errorf("world bounds %v to %v are empty", minPoint, maxPoint)
+	}
+
+	w := NewWorld()
+	air := MustBlock("air")
+
+	for x := minPoint.X; x <= maxPoint.X; x++ {
+		for y := minPoint.Y; y <= maxPoint.Y; y++ {
+			for z := minPoint.Z; z <= maxPoint.Z; z++ {
+				w.SetBlock(pathfinding.Point{X: x, Y: y, Z: z}, air)
+			}
+		}
+	}
+
+	if err := w.Apply(regions, blocks); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *World) Apply(regions []Region, blocks []BlockData) error {
+	regionBlocks := make([]Block, len(regions))
+	for i, region := range regions {
+		block, ok := NewBlock(region.Type)
+		if !ok {
+			return fmt.Errorf("region %d has unknown block type %q", i, region.Type)
+		}
+		if !w.InBounds(region.Min) || !w.InBounds(region.Max) {
+			return fmt.Errorf("region %d from %v to %v lies outside the world bounds", i, region.Min, region.Max)
+		}
+		regionBlocks[i] = block
+	}
+
+	singleBlocks := make([]Block, len(blocks))
+	for i, data := range blocks {
+		p := pathfinding.Point{X: data.X, Y: data.Y, Z: data.Z}
+
+		block, ok := NewBlock(data.Type)
+		if !ok {
+			return fmt.Errorf("block at %v has unknown type %q", p, data.Type)
+		}
+		if !w.InBounds(p) {
+			return fmt.Errorf("block at %v lies outside the world bounds", p)
+		}
+		singleBlocks[i] = block
+	}
+
+	for i, region := range regions {
+		for x := min(region.Min.X, region.Max.X); x <= max(region.Min.X, region.Max.X); x++ {
+			for y := min(region.Min.Y, region.Max.Y); y <= max(region.Min.Y, region.Max.Y); y++ {
+				for z := min(region.Min.Z, region.Max.Z); z <= max(region.Min.Z, region.Max.Z); z++ {
+					w.SetBlock(pathfinding.Point{X: x, Y: y, Z: z}, regionBlocks[i])
+				}
+			}
+		}
+	}
+
+	for i, data := range blocks {
+		w.SetBlock(pathfinding.Point{X: data.X, Y: data.Y, Z: data.Z}, singleBlocks[i])
+	}
+
+	return nil
+}
+
+func BlockListBounds(blocks []BlockData, regions []Region, extra ...pathfinding.Point) (pathfinding.Point, pathfinding.Point, bool) {
+	points := append([]pathfinding.Point{}, extra...)
+	for _, data := range blocks {
+		points = append(points, pathfinding.Point{X: data.X, Y: data.Y, Z: data.Z})
+	}
+	for _, region := range regions {
+		points = append(points, region.Min, region.Max)
+	}
+
+	if len(points) == 0 {
+		return pathfinding.Point{}, pathfinding.Point{}, false
+	}
+
+	minPoint, maxPoint := points[0], points[0]
+	for _, p := range points[1:] {
+		minPoint = pathfinding.Point{X: min(minPoint.X, p.X), Y: min(minPoint.Y, p.Y), Z: min(minPoint.Z, p.Z)}
+		maxPoint = pathfinding.Point{X: max(maxPoint.X, p.X), Y: max(maxPoint.Y, p.Y), Z: max(maxPoint.Z, p.Z)}
+	}
+
+	return minPoint, maxPoint, true
+}
@@ -0,0 +1,344 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type GeneratorOptions struct {
+	Seed     int64
+	SizeX    int
+	SizeZ    int
+	Height   int
+	SeaLevel int
+	Caves    bool
+	Rivers   bool
+	Ores     bool
+	Trees    bool
+	Lava     bool
+}
+
+func DefaultGeneratorOptions(seed int64) GeneratorOptions {
+	return GeneratorOptions{
+		Seed:     seed,
+		SizeX:    41,
+		SizeZ:    41,
+		Height:   16,
+		SeaLevel: 4,
+		Caves:    true,
+		Rivers:   true,
+		Ores:     true,
+		Trees:    true,
+		Lava:     true,
+	}
+}
+
+type terrainGenerator struct {
+	options GeneratorOptions
+	rng     *rand.Rand
+	world   *World
+	heights map[[2]int]int
+
+	terrain *valueNoise
+	river   *valueNoise
+	cave    *valueNoise
+	lava    *valueNoise
+
+	minX, maxX int
+	minZ, maxZ int
+	snowLine   int
+}
+
+func Generate(options GeneratorOptions) *World {
+	if options.SizeX <= 0 {
+		options.SizeX = 41
+	}
+	if options.SizeZ <= 0 {
+		options.SizeZ = 41
+	}
+	if options.Height < 8 {
+		options.Height = 8
+	}
+	if options.SeaLevel <= 0 || options.SeaLevel >= options.Height-4 {
+		options.SeaLevel = options.Height / 4
+	}
+
+	rng := rand.New(rand.NewSource(options.Seed))
+
+	g := &terrainGenerator{
+		options: options,
+		rng:     rng,
+		world:   NewWorld(),
+		heights: make(map[[2]int]int),
+		terrain: newValueNoise(rng),
+		river:   newValueNoise(rng),
+		cave:    newValueNoise(rng),
+		lava:    newValueNoise(rng),
+		minX:    -options.SizeX / 2,
+		minZ:    -options.SizeZ / 2,
+	}
+	g.maxX = g.minX + options.SizeX - 1
+	g.maxZ = g.minZ + options.SizeZ - 1
+	g.snowLine = options.Height - 6
+
+	g.buildHeightmap()
+	g.fillColumns()
+
+	if options.Caves {
+		g.carveCaves()
+	}
+	if options.Ores {
+		g.placeOres()
+	}
+	if options.Lava {
+		g.placeLavaPools()
+	}
+	if options.Trees {
+		g.plantTrees()
+	}
+
+	return g.world
+}
+
+func (g *terrainGenerator) buildHeightmap() {
+	amplitude := float64(g.options.Height-g.options.SeaLevel) * 0.9
+	maxSurface := g.options.Height - 6
+
+	for x := g.minX; x <= g.maxX; x++ {
+		for z := g.minZ; z <= g.maxZ; z++ {
+			n := g.terrain.fractal2(float64(x)*0.06, float64(z)*0.06, 4)
+			h := g.options.SeaLevel + 1 + int(math.Round(n*amplitude))
+
+			if g.options.Rivers {
+				r := math.Abs(g.river.fractal2(float64(x)*0.035, float64(z)*0.035, 3))
+				if r < 0.05 {
+					h = min(h, g.options.SeaLevel-1)
+				} else if r < 0.09 {
+					h = min(h, g.options.SeaLevel)
+				}
+			}
+
+			g.heights[[2]int{x, z}] = clamp(h, 1, maxSurface)
+		}
+	}
+}
+
+func (g *terrainGenerator) fillColumns() {
+	for x := g.minX; x <= g.maxX; x++ {
+		for z := g.minZ; z <= g.maxZ; z++ {
+			h := g.heights[[2]int{x, z}]
+
+			for y := 0; y <= g.options.Height; y++ {
+				var block Block
+
+				switch {
+				case y < h:
+					block = MustBlock("stone")
+				case y == h && h < g.options.SeaLevel:
+					block = MustBlock("stone")
+				case y == h:
+					block = MustBlock("grass")
+				case y <= g.options.SeaLevel:
+					block = MustBlock("water")
+				case y == h+1 && h <= g.options.SeaLevel+1:
+					block = MustBlock("sand")
+				case y == h+1 && h >= g.snowLine:
+					block = MustBlock("ice")
+				default:
+					block = MustBlock("air")
+				}
+
+				g.set(x, y, z, block)
+			}
+		}
+	}
+}
+
+func (g *terrainGenerator) carveCaves() {
+	for x := g.minX; x <= g.maxX; x++ {
+		for z := g.minZ; z <= g.maxZ; z++ {
+			h := g.heights[[2]int{x, z}]
+
+			for y := 1; y < h-1; y++ {
+				c := g.cave.fractal3(float64(x)*0.12, float64(y)*0.2, float64(z)*0.12, 2)
+				if c <= 0.3 {
+					continue
+				}
+
+				if g.options.Lava && y == 1 {
+					g.set(x, y, z, MustBlock("lava"))
+				} else {
+					g.set(x, y, z, MustBlock("air"))
+				}
+			}
+		}
+	}
+}
+
+func (g *terrainGenerator) placeOres() {
+	pockets := g.options.SizeX * g.options.SizeZ / 60
+
+	for i := 0; i < pockets; i++ {
+		cx := g.minX + g.rng.Intn(g.options.SizeX)
+		cz := g.minZ + g.rng.Intn(g.options.SizeZ)
+		h := g.heights[[2]int{cx, cz}]
+		if h < 3 {
+			continue
+		}
+		cy := 1 + g.rng.Intn(h-2)
+
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for dz := -1; dz <= 1; dz++ {
+					if abs(dx)+abs(dy)+abs(dz) > 1 || g.rng.Float64() > 0.7 {
+						continue
+					}
+
+					p := pathfinding.Point{X: cx + dx, Y: cy + dy, Z: cz + dz}
+					if g.world.GetBlockType(p) == "stone" && p.Y > 0 {
+						g.world.SetBlock(p, MustBlock("grass"))
+					}
+				}
+			}
+		}
+	}
+}
+
+func (g *terrainGenerator) placeLavaPools() {
+	for x := g.minX; x <= g.maxX; x++ {
+		for z := g.minZ; z <= g.maxZ; z++ {
+			h := g.heights[[2]int{x, z}]
+			if h <= g.options.SeaLevel+1 || h >= g.snowLine {
+				continue
+			}
+
+			if g.lava.fractal2(float64(x)*0.09, float64(z)*0.09, 2) > 0.5 {
+				g.set(x, h+1, z, MustBlock("lava"))
+			}
+		}
+	}
+}
+
+func (g *terrainGenerator) plantTrees() {
+	var trees [][2]int
+
+	for x := g.minX + 2; x <= g.maxX-2; x++ {
+		for z := g.minZ + 2; z <= g.maxZ-2; z++ {
+			if g.rng.Float64() >= 0.025 {
+				continue
+			}
+
+			h := g.heights[[2]int{x, z}]
+			surface := pathfinding.Point{X: x, Y: h, Z: z}
+			if g.world.GetBlockType(surface) != "grass" ||
+				g.world.GetBlockType(pathfinding.Point{X: x, Y: h + 1, Z: z}) != "air" {
+				continue
+			}
+
+			crowded := false
+			for _, t := range trees {
+				if abs(t[0]-x) < 4 && abs(t[1]-z) < 4 {
+					crowded = true
+					break
+				}
+			}
+			if crowded {
+				continue
+			}
+
+			trunkHeight := 3 + g.rng.Intn(2)
+			top := h + trunkHeight
+			if top+1 > g.options.Height {
+				continue
+			}
+
+			for y := h + 1; y <= top; y++ {
+				g.set(x, y, z, MustBlock("wood"))
+			}
+
+			for dx := -1; dx <= 1; dx++ {
+				for dz := -1; dz <= 1; dz++ {
+					for y := top - 1; y <= top+1; y++ {
+						p := pathfinding.Point{X: x + dx, Y: y, Z: z + dz}
+						if g.world.GetBlockType(p) == "air" {
+							g.world.SetBlock(p, MustBlock("wood"))
+						}
+					}
+				}
+			}
+
+			trees = append(trees, [2]int{x, z})
+		}
+	}
+}
+
+func SpawnPoints(w *World) (pathfinding.Point, pathfinding.Point, bool) {
+	lo, hi := w.Bounds()
+
+	start, ok := nearestStandable(w, lo.X+2, lo.Z+2, 1, 1)
+	if !ok {
+		return pathfinding.Point{}, pathfinding.Point{}, false
+	}
+
+	goal, ok := nearestStandable(w, hi.X-2, hi.Z-2, -1, -1)
+	if !ok {
+		return pathfinding.Point{}, pathfinding.Point{}, false
+	}
+
+	return start, goal, true
+}
+
+func nearestStandable(w *World, x, z, dx, dz int) (pathfinding.Point, bool) {
+	lo, hi := w.Bounds()
+
+	for step := 0; ; step++ {
+		cx, cz := x+dx*step, z+dz*step
+		if cx < lo.X || cx > hi.X || cz < lo.Z || cz > hi.Z {
+			return pathfinding.Point{}, false
+		}
+
+		for i := 0; i <= step; i++ {
+			for _, c := range [][2]int{{x + dx*i, cz}, {cx, z + dz*i}} {
+				if p, ok := StandableAt(w, c[0], c[1]); ok {
+					return p, true
+				}
+			}
+		}
+	}
+}
+
+func StandableAt(w *World, x, z int) (pathfinding.Point, bool) {
+	p, ok := w.SurfaceAt(x, z)
+	if !ok || w.GetBlockType(p) == "water" {
+		return pathfinding.Point{}, false
+	}
+
+	if w.GetBlockType(pathfinding.Point{X: p.X, Y: p.Y - 1, Z: p.Z}) == "lava" {
+		return pathfinding.Point{}, false
+	}
+
+	return p, true
+}
+
+func (g *terrainGenerator) set(x, y, z int, block Block) {
+	g.world.SetBlock(pathfinding.Point{X: x, Y: y, Z: z}, block)
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
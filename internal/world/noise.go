@@ -0,0 +1,92 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+)
+
+type valueNoise struct {
+	perm   [512]int
+	values [256]float64
+}
+
+func newValueNoise(rng *rand.Rand) *valueNoise {
+	n := &valueNoise{}
+
+	p := rng.Perm(256)
+	for i := 0; i < 512; i++ {
+		n.perm[i] = p[i&255]
+	}
+
+	for i := range n.values {
+		n.values[i] = rng.Float64()*2 - 1
+	}
+
+	return n
+}
+
+func (n *valueNoise) lattice2(x, z int) float64 {
+	return n.values[n.perm[n.perm[x&255]+(z&255)]]
+}
+
+func (n *valueNoise) lattice3(x, y, z int) float64 {
+	return n.values[n.perm[n.perm[n.perm[x&255]+(y&255)]+(z&255)]]
+}
+
+func (n *valueNoise) at2(x, z float64) float64 {
+	x0, z0 := math.Floor(x), math.Floor(z)
+	ix, iz := int(x0), int(z0)
+	tx, tz := smoothstep(x-x0), smoothstep(z-z0)
+
+	a := lerp(n.lattice2(ix, iz), n.lattice2(ix+1, iz), tx)
+	b := lerp(n.lattice2(ix, iz+1), n.lattice2(ix+1, iz+1), tx)
+
+	return lerp(a, b, tz)
+}
+
+func (n *valueNoise) at3(x, y, z float64) float64 {
+	x0, y0, z0 := math.Floor(x), math.Floor(y), math.Floor(z)
+	ix, iy, iz := int(x0), int(y0), int(z0)
+	tx, ty, tz := smoothstep(x-x0), smoothstep(y-y0), smoothstep(z-z0)
+
+	c00 := lerp(n.lattice3(ix, iy, iz), n.lattice3(ix+1, iy, iz), tx)
+	c10 := lerp(n.lattice3(ix, iy+1, iz), n.lattice3(ix+1, iy+1, iz), tx)
+	c01 := lerp(n.lattice3(ix, iy, iz+1), n.lattice3(ix+1, iy, iz+1), tx)
+	c11 := lerp(n.lattice3(ix, iy+1, iz+1), n.lattice3(ix+1, iy+1, iz+1), tx)
+
+	return lerp(lerp(c00, c10, ty), lerp(c01, c11, ty), tz)
+}
+
+func (n *valueNoise) fractal2(x, z float64, octaves int) float64 {
+	total, amplitude, frequency, norm := 0.0, 1.0, 1.0, 0.0
+
+	for i := 0; i < octaves; i++ {
+		total += n.at2(x*frequency, z*frequency) * amplitude
+		norm += amplitude
+		amplitude *= 0.5
+		frequency *= 2
+	}
+
+	return total / norm
+}
+
+func (n *valueNoise) fractal3(x, y, z float64, octaves int) float64 {
+	total, amplitude, frequency, norm := 0.0, 1.0, 1.0, 0.0
+
+	for i := 0; i < octaves; i++ {
+		total += n.at3(x*frequency, y*frequency, z*frequency) * amplitude
+		norm += amplitude
+		amplitude *= 0.5
+		frequency *= 2
+	}
+
+	return total / norm
+}
+
+func smoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
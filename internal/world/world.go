@@ -1,6 +1,8 @@
 package world
 
 import (
+	"math"
+
 	"github.com/WillKirkmanM/paritone/internal/pathfinding"
 )
 
@@ -13,6 +15,10 @@ type Block struct {
 
 type World struct {
 	Blocks map[pathfinding.Point]Block
+
+	min  pathfinding.Point
+	max  pathfinding.Point
+	hash uint64
 }
 
 func NewWorld() *World {
@@ -22,9 +28,81 @@ func NewWorld() *World {
 }
 
 func (w *World) SetBlock(p pathfinding.Point, block Block) {
+	if len(w.Blocks) == 0 {
+		w.min, w.max = p, p
+	} else {
+		w.min = pathfinding.Point{X: min(w.min.X, p.X), Y: min(w.min.Y, p.Y), Z: min(w.min.Z, p.Z)}
+		w.max = pathfinding.Point{X: max(w.max.X, p.X), Y: max(w.max.Y, p.Y), Z: max(w.max.Z, p.Z)}
+	}
+
+	if old, exists := w.Blocks[p]; exists {
+		w.hash ^= cellHash(p, old)
+	}
+	w.hash ^= cellHash(p, block)
+
 	w.Blocks[p] = block
 }
 
+func (w *World) Hash() uint64 {
+	return w.hash
+}
+
+func cellHash(p pathfinding.Point, block Block) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(block.Type); i++ {
+		h ^= uint64(block.Type[i])
+		h *= 1099511628211
+	}
+
+	var flags uint64
+	if block.Walkable {
+		flags |= 1
+	}
+	if block.Breakable {
+		flags |= 2
+	}
+
+	h ^= mix64(math.Float64bits(block.MoveCost) ^ flags<<62)
+	h ^= mix64(uint64(int64(p.X)))
+	h = mix64(h ^ mix64(uint64(int64(p.Y))+0x9e3779b97f4a7c15))
+	return mix64(h ^ mix64(uint64(int64(p.Z))+0xbf58476d1ce4e5b9))
+}
+
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func (w *World) Bounds() (pathfinding.Point, pathfinding.Point) {
+	return w.min, w.max
+}
+
+func (w *World) InBounds(p pathfinding.Point) bool {
+	return len(w.Blocks) > 0 &&
+		p.X >= w.min.X && p.X <= w.max.X &&
+		p.Y >= w.min.Y && p.Y <= w.max.Y &&
+		p.Z >= w.min.Z && p.Z <= w.max.Z
+}
+
+func (w *World) SurfaceAt(x, z int) (pathfinding.Point, bool) {
+	for y := w.max.Y; y > w.min.Y; y-- {
+		p := pathfinding.Point{X: x, Y: y, Z: z}
+		below := pathfinding.Point{X: x, Y: y - 1, Z: z}
+
+		if w.IsWalkable(p) && !w.IsWalkable(below) {
+			if _, exists := w.Blocks[below]; exists {
+				return p, true
+			}
+		}
+	}
+
+	return pathfinding.Point{}, false
+}
+
 func (w *World) GetBlock(p pathfinding.Point) (Block, bool) {
 	block, exists := w.Blocks[p]
 	return block, exists
@@ -0,0 +1,314 @@
+// Package api defines the request and response types of the Paritone HTTP
+// API, version 1. The server, the OpenAPI document served at
+// /api/v1/openapi.json and the Go client in package client all use these
+// types, so they cannot drift apart.
+//
+// Within v1, fields are only ever added. Existing fields keep their names,
+// types and meaning, and new request fields default to the old behaviour
+// when omitted.
+package api
+
+import (
+	"github.com/WillKirkmanM/paritone/internal/jobs"
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+	"github.com/WillKirkmanM/paritone/internal/session"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+const Version = "v1"
+
+// Bool returns a pointer to v, for the optional fields of PathRequest.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to v, for optional integer parameters.
+func Int(v int) *int {
+	return &v
+}
+
+type (
+	Point     = pathfinding.Point
+	BlockData = world.BlockData
+	Region    = world.Region
+	WorldInfo = session.Info
+	Job       = jobs.Snapshot
+	JobStatus = jobs.Status
+)
+
+const (
+	JobQueued    = jobs.StatusQueued
+	JobRunning   = jobs.StatusRunning
+	JobDone      = jobs.StatusDone
+	JobFailed    = jobs.StatusFailed
+	JobCancelled = jobs.StatusCancelled
+)
+
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeInvalidCoordinates = "invalid_coordinates"
+	CodeInvalidOptions     = "invalid_options"
+	CodeUnknownAlgorithm   = "unknown_algorithm"
+	CodeStartNotWalkable   = "start_not_walkable"
+	CodeGoalNotWalkable    = "goal_not_walkable"
+	CodeStartOutOfBounds   = "start_out_of_bounds"
+	CodeGoalOutOfBounds    = "goal_out_of_bounds"
+	CodeBudgetExceeded     = "budget_exceeded"
+	CodeNoPath             = "no_path"
+	CodeNegativeCycle      = "negative_cycle"
+	CodeWorldTooLarge      = "world_too_large"
+	CodeRequestTooLarge    = "request_too_large"
+	CodeBudgetTooLarge     = "budget_too_large"
+	CodeRateLimited        = "rate_limited"
+	CodeServerBusy         = "server_busy"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeStoreFull          = "store_full"
+	CodeQueueFull          = "queue_full"
+	CodeUnavailable        = "unavailable"
+	CodeInternal           = "internal"
+)
+
+// Error is the body of every failed response, wrapped in ErrorResponse.
+// Code is stable and meant for programs; Message is for people.
+type Error struct {
+	Status  int                    `json:"status"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type ErrorResponse struct {
+	Error *Error `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// With returns a copy of e with one more entry in Details.
+func (e *Error) With(key string, value interface{}) *Error {
+	details := make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[key] = value
+
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// PathRequest describes one search. Options left empty or nil take the
+// server's configured defaults.
+type PathRequest struct {
+	StartX        int        `json:"startX"`
+	StartY        int        `json:"startY"`
+	StartZ        int        `json:"startZ"`
+	EndX          int        `json:"endX"`
+	EndY          int        `json:"endY"`
+	EndZ          int        `json:"endZ"`
+	Algorithm     string     `json:"algorithm,omitempty"`
+	AllowBreaking *bool      `json:"allowBreaking,omitempty"`
+	AllowPlacing  *bool      `json:"allowPlacing,omitempty"`
+	AvoidWater    *bool      `json:"avoidWater,omitempty"`
+	MinVertical   *bool      `json:"minimiseVertical,omitempty"`
+	Algorithms    []string   `json:"algorithms,omitempty"`
+	MaxIterations int        `json:"maxIterations,omitempty"`
+	MaxNodes      int        `json:"maxNodes,omitempty"`
+	MaxFrontier   int        `json:"maxFrontier,omitempty"`
+	World         *WorldSpec `json:"world,omitempty"`
+}
+
+type WorldSpec struct {
+	Min      *Point      `json:"min,omitempty"`
+	Max      *Point      `json:"max,omitempty"`
+	Blocks   []BlockData `json:"blocks,omitempty"`
+	Regions  []Region    `json:"regions,omitempty"`
+	Seed     *int64      `json:"seed,omitempty"`
+	Scenario string      `json:"scenario,omitempty"`
+	ID       string      `json:"id,omitempty"`
+}
+
+type PathResponse struct {
+	Path            []Point  `json:"path"`
+	ComputationTime int64    `json:"computationTime"`
+	NodesExplored   int      `json:"nodesExplored"`
+	BlocksTraversed int      `json:"blocksTraversed"`
+	BlocksBroken    []Point  `json:"blocksBroken"`
+	BlocksPlaced    []Point  `json:"blocksPlaced"`
+	WaterCrossed    int      `json:"waterCrossed"`
+	VerticalChange  int      `json:"verticalChange"`
+	EstimatedTime   float64  `json:"estimatedTime"`
+	TotalCost       float64  `json:"totalCost"`
+	Actions         []Action `json:"actions"`
+	Cached          bool     `json:"cached"`
+}
+
+type Action struct {
+	Type     string `json:"type"`
+	From     Point  `json:"from"`
+	To       Point  `json:"to"`
+	Block    string `json:"block,omitempty"`
+	Face     string `json:"face,omitempty"`
+	Duration int64  `json:"duration"`
+}
+
+type WorldResponse struct {
+	Seed   int64       `json:"seed"`
+	Min    Point       `json:"min"`
+	Max    Point       `json:"max"`
+	Start  *Point      `json:"start,omitempty"`
+	Goal   *Point      `json:"goal,omitempty"`
+	Blocks []BlockData `json:"blocks"`
+}
+
+type AlgorithmComparison struct {
+	Algorithm             string   `json:"algorithm"`
+	Label                 string   `json:"label"`
+	Optimal               bool     `json:"optimal"`
+	Found                 bool     `json:"found"`
+	Path                  []Point  `json:"path"`
+	PathLength            int      `json:"pathLength"`
+	TotalCost             float64  `json:"totalCost"`
+	NodesExplored         int      `json:"nodesExplored"`
+	ComputationTime       int64    `json:"computationTime"`
+	ComputationTimeMicros int64    `json:"computationTimeMicros"`
+	MaxMemoryUsed         int      `json:"maxMemoryUsed"`
+	OptimalityGap         *float64 `json:"optimalityGap,omitempty"`
+	Cached                bool     `json:"cached"`
+	Error                 *Error   `json:"error,omitempty"`
+}
+
+type ComparisonSummary struct {
+	BestOptimalCost *float64 `json:"bestOptimalCost,omitempty"`
+	ByCost          []string `json:"byCost"`
+	ByNodesExplored []string `json:"byNodesExplored"`
+	ByTime          []string `json:"byTime"`
+	ByMemory        []string `json:"byMemory"`
+	Unsolved        []string `json:"unsolved,omitempty"`
+}
+
+type ComparisonResponse struct {
+	Results []AlgorithmComparison `json:"results"`
+	Summary ComparisonSummary     `json:"summary"`
+}
+
+type BatchQuery struct {
+	ID string `json:"id,omitempty"`
+	PathRequest
+}
+
+type BatchRequest struct {
+	World       *WorldSpec   `json:"world"`
+	Queries     []BatchQuery `json:"queries"`
+	Parallelism int          `json:"parallelism,omitempty"`
+}
+
+type BatchResult struct {
+	Index     int           `json:"index"`
+	ID        string        `json:"id,omitempty"`
+	Algorithm string        `json:"algorithm"`
+	Found     bool          `json:"found"`
+	Result    *PathResponse `json:"result,omitempty"`
+	Error     *Error        `json:"error,omitempty"`
+}
+
+type BatchAlgorithmSummary struct {
+	Queries                   int     `json:"queries"`
+	Found                     int     `json:"found"`
+	MeanNodesExplored         float64 `json:"meanNodesExplored"`
+	MeanComputationTimeMicros float64 `json:"meanComputationTimeMicros"`
+}
+
+type BatchSummary struct {
+	Queries                    int                              `json:"queries"`
+	Found                      int                              `json:"found"`
+	Failed                     int                              `json:"failed"`
+	Parallelism                int                              `json:"parallelism"`
+	TotalNodesExplored         int                              `json:"totalNodesExplored"`
+	TotalComputationTimeMicros int64                            `json:"totalComputationTimeMicros"`
+	WallTimeMicros             int64                            `json:"wallTimeMicros"`
+	MeanPathLength             float64                          `json:"meanPathLength"`
+	MeanTotalCost              float64                          `json:"meanTotalCost"`
+	ByAlgorithm                map[string]BatchAlgorithmSummary `json:"byAlgorithm"`
+	Errors                     map[string]int                   `json:"errors,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+	Summary BatchSummary  `json:"summary"`
+}
+
+type StreamStartEvent struct {
+	Algorithm string `json:"algorithm"`
+	Start     Point  `json:"start"`
+	Goal      Point  `json:"goal"`
+}
+
+type StreamProgressEvent struct {
+	NodesExplored int      `json:"nodesExplored"`
+	FrontierSize  int      `json:"frontierSize"`
+	BestFScore    *float64 `json:"bestFScore,omitempty"`
+	Bound         *float64 `json:"bound,omitempty"`
+	Iteration     int      `json:"iteration,omitempty"`
+	Current       Point    `json:"current"`
+	Frontier      []Point  `json:"frontier,omitempty"`
+	Elapsed       int64    `json:"elapsed"`
+}
+
+type JobRequest struct {
+	Type    string      `json:"type,omitempty"`
+	Request PathRequest `json:"request"`
+}
+
+type JobProgress struct {
+	Algorithm     string   `json:"algorithm"`
+	NodesExplored int      `json:"nodesExplored"`
+	FrontierSize  int      `json:"frontierSize"`
+	BestFScore    *float64 `json:"bestFScore,omitempty"`
+	Iteration     int      `json:"iteration,omitempty"`
+	Current       Point    `json:"current"`
+	Elapsed       int64    `json:"elapsed"`
+}
+
+type ScenarioSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Start       Point  `json:"start"`
+	Goal        Point  `json:"goal"`
+}
+
+type ScenarioResponse struct {
+	ScenarioSummary
+	Min    Point       `json:"min"`
+	Max    Point       `json:"max"`
+	Blocks []BlockData `json:"blocks"`
+}
+
+type CreateWorldRequest struct {
+	ID    string    `json:"id,omitempty"`
+	World WorldSpec `json:"world"`
+}
+
+type WorldEditRequest struct {
+	Blocks  []BlockData `json:"blocks,omitempty"`
+	Regions []Region    `json:"regions,omitempty"`
+}
+
+type WorldDetailResponse struct {
+	WorldInfo
+	Blocks []BlockData `json:"blocks"`
+}
+
+type HealthResponse struct {
+	Status           string `json:"status"`
+	SearchesInFlight int    `json:"searchesInFlight"`
+	SearchSlots      int    `json:"searchSlots"`
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/export"
+)
+
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// Route describes one operation of the API. Request and Response hold a zero
+// value of the body type, or nil when there is no JSON body. Exports marks
+// routes whose response can also be rendered by the path exporters.
+type Route struct {
+	Name        string
+	Method      string
+	Path        string
+	Summary     string
+	Query       []Param
+	Request     interface{}
+	Response    interface{}
+	Status      int
+	ContentType string
+	Exports     bool
+}
+
+const Prefix = "/api/" + Version
+
+var Routes = []Route{
+	{Name: "findPath", Method: "POST", Path: Prefix + "/find-path",
+		Summary: "Find a path between two points",
+		Query: []Param{
+			{"format", "string", "json (default), csv, jsonl, mcfunction or baritone; overrides the Accept header"},
+		},
+		Request: PathRequest{}, Response: PathResponse{}, Exports: true},
+	{Name: "findPathStream", Method: "POST", Path: Prefix + "/find-path/stream",
+		Summary: "Find a path, streaming start, progress and result or error events",
+		Query: []Param{
+			{"interval", "integer", "Nodes between progress events"},
+			{"frontier", "integer", "Frontier points to include in each progress event"},
+			{"rate", "integer", "Minimum milliseconds between progress events"},
+		},
+		Request: PathRequest{}, Response: StreamProgressEvent{}, ContentType: "text/event-stream"},
+	{Name: "findPaths", Method: "POST", Path: Prefix + "/find-paths",
+		Summary: "Run many path queries against one world", Request: BatchRequest{}, Response: BatchResponse{}},
+	{Name: "compareAlgorithms", Method: "POST", Path: Prefix + "/compare-algorithms",
+		Summary: "Run several algorithms on the same query and rank them", Request: PathRequest{}, Response: ComparisonResponse{}},
+	{Name: "generateWorld", Method: "GET", Path: Prefix + "/generate-world",
+		Summary: "Generate a procedural world",
+		Query: []Param{
+			{"seed", "integer", "Generator seed, random when omitted"},
+			{"sizeX", "integer", "Width of the world"},
+			{"sizeZ", "integer", "Depth of the world"},
+			{"height", "integer", "Maximum terrain height"},
+			{"seaLevel", "integer", "Water level"},
+			{"caves", "boolean", "Carve caves"},
+			{"rivers", "boolean", "Carve rivers"},
+			{"ores", "boolean", "Place ores"},
+			{"trees", "boolean", "Grow trees"},
+			{"lava", "boolean", "Place lava"},
+		},
+		Response: WorldResponse{}},
+	{Name: "listScenarios", Method: "GET", Path: Prefix + "/scenarios",
+		Summary: "List the built-in scenarios", Response: []ScenarioSummary{}},
+	{Name: "getScenario", Method: "GET", Path: Prefix + "/scenarios/{id}",
+		Summary: "Get a built-in scenario with its blocks", Response: ScenarioResponse{}},
+	{Name: "listWorlds", Method: "GET", Path: Prefix + "/worlds",
+		Summary: "List stored worlds", Response: []WorldInfo{}},
+	{Name: "createWorld", Method: "POST", Path: Prefix + "/worlds",
+		Summary: "Store a world for later searches and edits", Request: CreateWorldRequest{}, Response: WorldInfo{},
+		Status: http.StatusCreated},
+	{Name: "getWorld", Method: "GET", Path: Prefix + "/worlds/{id}",
+		Summary: "Get a stored world with its blocks", Response: WorldDetailResponse{}},
+	{Name: "deleteWorld", Method: "DELETE", Path: Prefix + "/worlds/{id}",
+		Summary: "Delete a stored world", Status: http.StatusNoContent},
+	{Name: "getWorldBlocks", Method: "GET", Path: Prefix + "/worlds/{id}/blocks",
+		Summary: "List the blocks of a stored world", Response: []BlockData{}},
+	{Name: "replaceWorldBlocks", Method: "PUT", Path: Prefix + "/worlds/{id}/blocks",
+		Summary: "Replace the contents of a stored world", Request: WorldSpec{}, Response: WorldInfo{}},
+	{Name: "editWorldBlocks", Method: "PATCH", Path: Prefix + "/worlds/{id}/blocks",
+		Summary: "Set individual blocks and regions in a stored world", Request: WorldEditRequest{}, Response: WorldInfo{}},
+	{Name: "listJobs", Method: "GET", Path: Prefix + "/jobs",
+		Summary: "List asynchronous jobs", Response: []Job{}},
+	{Name: "submitJob", Method: "POST", Path: Prefix + "/jobs",
+		Summary: "Queue a path or compare job", Request: JobRequest{}, Response: Job{}, Status: http.StatusAccepted},
+	{Name: "getJob", Method: "GET", Path: Prefix + "/jobs/{id}",
+		Summary: "Poll a job for progress and its result", Response: Job{}},
+	{Name: "cancelJob", Method: "DELETE", Path: Prefix + "/jobs/{id}",
+		Summary: "Cancel a job", Response: Job{}},
+	{Name: "getOpenAPI", Method: "GET", Path: Prefix + "/openapi.json",
+		Summary: "This document"},
+	{Name: "health", Method: "GET", Path: "/healthz",
+		Summary: "Liveness probe", Response: HealthResponse{}},
+	{Name: "ready", Method: "GET", Path: "/readyz",
+		Summary: "Readiness probe, 503 while shutting down", Response: HealthResponse{}},
+}
+
+var schemaNames = map[reflect.Type]string{
+	reflect.TypeOf(Job{}):       "Job",
+	reflect.TypeOf(WorldInfo{}): "WorldInfo",
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// OpenAPI builds the OpenAPI 3 document for Routes, deriving every schema
+// from the Go types above.
+func OpenAPI() map[string]interface{} {
+	schemas := &schemaSet{components: make(map[string]interface{})}
+	paths := make(map[string]interface{})
+
+	errorSchema := schemas.of(reflect.TypeOf(ErrorResponse{}))
+
+	for _, route := range Routes {
+		operation := map[string]interface{}{
+			"operationId": route.Name,
+			"summary":     route.Summary,
+		}
+
+		var parameters []interface{}
+		for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"name": match[1], "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+		for _, param := range route.Query {
+			parameters = append(parameters, map[string]interface{}{
+				"name": param.Name, "in": "query", "description": param.Description,
+				"schema": map[string]interface{}{"type": param.Type},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if route.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.of(reflect.TypeOf(route.Request))},
+				},
+			}
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := map[string]interface{}{"description": http.StatusText(status)}
+		switch {
+		case route.Name == "getOpenAPI":
+			success["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": map[string]interface{}{"type": "object"}},
+			}
+		case route.Response != nil:
+			contentType := route.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			content := map[string]interface{}{
+				contentType: map[string]interface{}{"schema": schemas.of(reflect.TypeOf(route.Response))},
+			}
+			if route.Exports {
+				for _, format := range export.Formats() {
+					mediaType, _, _ := strings.Cut(format.ContentType, ";")
+					content[mediaType] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+				}
+			}
+			success["content"] = content
+		}
+
+		operation["responses"] = map[string]interface{}{
+			strconv.Itoa(status): success,
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": errorSchema},
+				},
+			},
+		}
+
+		item, ok := paths[route.Path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Paritone API",
+			"version":     Version,
+			"description": "Pathfinding over 3D voxel worlds. Routes under /api/ without the version prefix are aliases of the v1 routes.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas.components},
+	}
+}
+
+type schemaSet struct {
+	components map[string]interface{}
+}
+
+func (s *schemaSet) of(t reflect.Type) map[string]interface{} {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.of(t.Elem())
+	case reflect.Struct:
+		name := schemaNames[t]
+		if name == "" {
+			name = t.Name()
+		}
+		if name == "" {
+			return s.object(t)
+		}
+		if _, seen := s.components[name]; !seen {
+			s.components[name] = nil // placeholder so recursive types terminate
+			s.components[name] = s.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	}
+
+	return map[string]interface{}{}
+}
+
+func (s *schemaSet) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+
+	s.fields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (s *schemaSet) fields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.fields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if _, seen := properties[name]; !seen && !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+		properties[name] = s.of(field.Type)
+	}
+}
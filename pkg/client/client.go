@@ -0,0 +1,386 @@
+// Package client is a typed Go client for the Paritone HTTP API, version 1.
+//
+//	c := client.New("http://localhost:8080")
+//	path, err := c.FindPath(ctx, api.PathRequest{StartX: 0, StartY: 5, EndX: 10, EndY: 5, Algorithm: "astar"})
+//
+// Failed requests return an *api.Error, so callers can branch on its Code.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/WillKirkmanM/paritone/pkg/api"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, for example to set a timeout or
+// a custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header to every request, such as X-Request-ID or an
+// authorisation header required by a proxy in front of the server.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Set(key, value)
+	}
+}
+
+// New returns a client for the server at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (c *Client) FindPath(ctx context.Context, req api.PathRequest) (*api.PathResponse, error) {
+	var response api.PathResponse
+	return result(&response, c.do(ctx, "POST", "/find-path", nil, req, &response))
+}
+
+// ExportPath finds a path and returns it rendered in one of the export
+// formats: csv, jsonl, mcfunction or baritone.
+func (c *Client) ExportPath(ctx context.Context, req api.PathRequest, format string) ([]byte, error) {
+	resp, err := c.send(ctx, "POST", "/find-path", url.Values{"format": {format}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *Client) FindPaths(ctx context.Context, req api.BatchRequest) (*api.BatchResponse, error) {
+	var response api.BatchResponse
+	return result(&response, c.do(ctx, "POST", "/find-paths", nil, req, &response))
+}
+
+func (c *Client) CompareAlgorithms(ctx context.Context, req api.PathRequest) (*api.ComparisonResponse, error) {
+	var response api.ComparisonResponse
+	return result(&response, c.do(ctx, "POST", "/compare-algorithms", nil, req, &response))
+}
+
+type StreamOptions struct {
+	Interval   *int
+	Frontier   *int
+	RateMillis *int
+}
+
+// FindPathStream runs a search and calls onProgress for every progress event
+// until the search finishes. A failed search is returned as an *api.Error.
+func (c *Client) FindPathStream(ctx context.Context, req api.PathRequest, options StreamOptions, onProgress func(api.StreamProgressEvent)) (*api.PathResponse, error) {
+	query := url.Values{}
+	setInt(query, "interval", options.Interval)
+	setInt(query, "frontier", options.Frontier)
+	setInt(query, "rate", options.RateMillis)
+
+	resp, err := c.send(ctx, "POST", "/find-path/stream", query, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	var event string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data := []byte(strings.TrimPrefix(line, "data: "))
+			switch event {
+			case "progress":
+				if onProgress == nil {
+					continue
+				}
+				var progress api.StreamProgressEvent
+				if err := json.Unmarshal(data, &progress); err != nil {
+					return nil, fmt.Errorf("decoding progress event: %w", err)
+				}
+				onProgress(progress)
+			case "result":
+				var response api.PathResponse
+				if err := json.Unmarshal(data, &response); err != nil {
+					return nil, fmt.Errorf("decoding result event: %w", err)
+				}
+				return &response, nil
+			case "error":
+				var failure api.ErrorResponse
+				if err := json.Unmarshal(data, &failure); err != nil || failure.Error == nil {
+					return nil, fmt.Errorf("decoding error event: %s", data)
+				}
+				return nil, failure.Error
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("stream ended without a result")
+}
+
+type WorldOptions struct {
+	Seed     *int64
+	SizeX    *int
+	SizeZ    *int
+	Height   *int
+	SeaLevel *int
+	Caves    *bool
+	Rivers   *bool
+	Ores     *bool
+	Trees    *bool
+	Lava     *bool
+}
+
+func (c *Client) GenerateWorld(ctx context.Context, options WorldOptions) (*api.WorldResponse, error) {
+	query := url.Values{}
+	if options.Seed != nil {
+		query.Set("seed", strconv.FormatInt(*options.Seed, 10))
+	}
+	setInt(query, "sizeX", options.SizeX)
+	setInt(query, "sizeZ", options.SizeZ)
+	setInt(query, "height", options.Height)
+	setInt(query, "seaLevel", options.SeaLevel)
+	setBool(query, "caves", options.Caves)
+	setBool(query, "rivers", options.Rivers)
+	setBool(query, "ores", options.Ores)
+	setBool(query, "trees", options.Trees)
+	setBool(query, "lava", options.Lava)
+
+	var response api.WorldResponse
+	return result(&response, c.do(ctx, "GET", "/generate-world", query, nil, &response))
+}
+
+func (c *Client) Scenarios(ctx context.Context) ([]api.ScenarioSummary, error) {
+	var response []api.ScenarioSummary
+	if err := c.do(ctx, "GET", "/scenarios", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *Client) Scenario(ctx context.Context, id string) (*api.ScenarioResponse, error) {
+	var response api.ScenarioResponse
+	return result(&response, c.do(ctx, "GET", "/scenarios/"+url.PathEscape(id), nil, nil, &response))
+}
+
+func (c *Client) Worlds(ctx context.Context) ([]api.WorldInfo, error) {
+	var response []api.WorldInfo
+	if err := c.do(ctx, "GET", "/worlds", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *Client) CreateWorld(ctx context.Context, req api.CreateWorldRequest) (*api.WorldInfo, error) {
+	var response api.WorldInfo
+	return result(&response, c.do(ctx, "POST", "/worlds", nil, req, &response))
+}
+
+func (c *Client) World(ctx context.Context, id string) (*api.WorldDetailResponse, error) {
+	var response api.WorldDetailResponse
+	return result(&response, c.do(ctx, "GET", "/worlds/"+url.PathEscape(id), nil, nil, &response))
+}
+
+func (c *Client) DeleteWorld(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/worlds/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) WorldBlocks(ctx context.Context, id string) ([]api.BlockData, error) {
+	var response []api.BlockData
+	if err := c.do(ctx, "GET", "/worlds/"+url.PathEscape(id)+"/blocks", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *Client) ReplaceWorldBlocks(ctx context.Context, id string, spec api.WorldSpec) (*api.WorldInfo, error) {
+	var response api.WorldInfo
+	return result(&response, c.do(ctx, "PUT", "/worlds/"+url.PathEscape(id)+"/blocks", nil, spec, &response))
+}
+
+func (c *Client) EditWorldBlocks(ctx context.Context, id string, edit api.WorldEditRequest) (*api.WorldInfo, error) {
+	var response api.WorldInfo
+	return result(&response, c.do(ctx, "PATCH", "/worlds/"+url.PathEscape(id)+"/blocks", nil, edit, &response))
+}
+
+func (c *Client) Jobs(ctx context.Context) ([]api.Job, error) {
+	var response []api.Job
+	if err := c.do(ctx, "GET", "/jobs", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *Client) SubmitJob(ctx context.Context, req api.JobRequest) (*api.Job, error) {
+	var response api.Job
+	return result(&response, c.do(ctx, "POST", "/jobs", nil, req, &response))
+}
+
+func (c *Client) Job(ctx context.Context, id string) (*api.Job, error) {
+	var response api.Job
+	return result(&response, c.do(ctx, "GET", "/jobs/"+url.PathEscape(id), nil, nil, &response))
+}
+
+func (c *Client) CancelJob(ctx context.Context, id string) (*api.Job, error) {
+	var response api.Job
+	return result(&response, c.do(ctx, "DELETE", "/jobs/"+url.PathEscape(id), nil, nil, &response))
+}
+
+func (c *Client) Health(ctx context.Context) (*api.HealthResponse, error) {
+	var response api.HealthResponse
+	return result(&response, c.probe(ctx, "/healthz", &response))
+}
+
+// Ready reports whether the server accepts work. While it shuts down Ready
+// returns an *api.Error with Code api.CodeUnavailable.
+func (c *Client) Ready(ctx context.Context) (*api.HealthResponse, error) {
+	var response api.HealthResponse
+	return result(&response, c.probe(ctx, "/readyz", &response))
+}
+
+func (c *Client) probe(ctx context.Context, path string, response *api.HealthResponse) error {
+	req, err := c.newRequest(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &api.Error{Status: resp.StatusCode, Code: api.CodeUnavailable, Message: "server is " + response.Status}
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, response interface{}) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if response == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("decoding %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	target := c.baseURL + api.Prefix + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := c.newRequest(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+func decodeError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	var failure api.ErrorResponse
+	if err := json.Unmarshal(data, &failure); err == nil && failure.Error != nil {
+		return failure.Error
+	}
+
+	message := strings.TrimSpace(string(data))
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &api.Error{Status: resp.StatusCode, Code: api.CodeInternal, Message: message}
+}
+
+func result[T any](response *T, err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func setInt(query url.Values, key string, value *int) {
+	if value != nil {
+		query.Set(key, strconv.Itoa(*value))
+	}
+}
+
+func setBool(query url.Values, key string, value *bool) {
+	if value != nil {
+		query.Set(key, strconv.FormatBool(*value))
+	}
+}
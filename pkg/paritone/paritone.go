@@ -0,0 +1,83 @@
+// Package paritone embeds the Paritone pathfinder in other Go programs. It
+// builds voxel worlds, runs any of the server's search algorithms against
+// them and reports the same statistics the HTTP API does, without a server.
+//
+//	w, _ := paritone.GenerateWorld(paritone.DefaultGeneratorOptions(42))
+//	start, goal, _ := w.SpawnPoints()
+//	result, err := paritone.FindPath(ctx, w, start, paritone.GoalBlock(goal), paritone.Options{Algorithm: paritone.AStar})
+//
+// # Compatibility
+//
+// The package follows semantic versioning with the module. Within a major
+// version:
+//
+//   - exported identifiers are not removed or renamed and function
+//     signatures do not change;
+//   - struct fields are only added, and the zero value of a new Options
+//     field keeps the previous behaviour;
+//   - algorithm names and block type names keep their meaning, and new ones
+//     may be added;
+//   - the error values below keep matching with errors.Is.
+//
+// Paths are only guaranteed to be optimal for algorithms whose Optimal flag
+// is set. Among equally good paths, which one is returned may change
+// between releases, as may NodesExplored and timings.
+package paritone
+
+import (
+	"errors"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+type Point = pathfinding.Point
+
+const (
+	AStar         = "astar"
+	Dijkstra      = "dijkstra"
+	BFS           = "bfs"
+	Greedy        = "greedy"
+	JPS           = "jps"
+	IDAStar       = "ida"
+	BellmanFord   = "bellmanford"
+	ThetaStar     = "theta"
+	Bidirectional = "bidirectional"
+)
+
+var (
+	ErrNoPath           = pathfinding.ErrNoPath
+	ErrStartBlocked     = pathfinding.ErrStartBlocked
+	ErrGoalBlocked      = pathfinding.ErrGoalBlocked
+	ErrIterationLimit   = pathfinding.ErrIterationLimit
+	ErrMemoryLimit      = pathfinding.ErrMemoryLimit
+	ErrNegativeCycle    = pathfinding.ErrNegativeCycle
+	ErrCancelled        = pathfinding.ErrCancelled
+	ErrUnknownAlgorithm = errors.New("unknown algorithm")
+	ErrUnknownBlock     = errors.New("unknown block type")
+	ErrOutOfBounds      = errors.New("point lies outside the world")
+)
+
+// Algorithm describes one search algorithm. Optimal algorithms always return
+// a lowest-cost path when one exists.
+type Algorithm struct {
+	Name    string
+	Label   string
+	Optimal bool
+}
+
+func Algorithms() []Algorithm {
+	internal := pathfinding.Algorithms()
+	algorithms := make([]Algorithm, len(internal))
+	for i, a := range internal {
+		algorithms[i] = Algorithm{Name: a.Name, Label: a.Label, Optimal: a.Optimal}
+	}
+	return algorithms
+}
+
+func LookupAlgorithm(name string) (Algorithm, bool) {
+	a, ok := pathfinding.LookupAlgorithm(name)
+	if !ok {
+		return Algorithm{}, false
+	}
+	return Algorithm{Name: a.Name, Label: a.Label, Optimal: a.Optimal}, true
+}
@@ -0,0 +1,202 @@
+package paritone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/WillKirkmanM/paritone/internal/pathfinding"
+)
+
+// Goal describes where a search may end. Use GoalBlock for a single cell,
+// GoalAny for the cheapest of several cells and GoalSurface for the top of
+// a column.
+type Goal interface {
+	candidates(w *World) ([]Point, error)
+}
+
+type goalBlock Point
+
+func GoalBlock(p Point) Goal {
+	return goalBlock(p)
+}
+
+func (g goalBlock) candidates(*World) ([]Point, error) {
+	return []Point{Point(g)}, nil
+}
+
+type goalAny []Point
+
+// GoalAny is reached at whichever of points has the cheapest path. Each
+// point is searched separately, so keep the list short; NodesExplored and
+// Duration in the result cover all of the searches.
+func GoalAny(points ...Point) Goal {
+	return goalAny(append([]Point(nil), points...))
+}
+
+func (g goalAny) candidates(*World) ([]Point, error) {
+	if len(g) == 0 {
+		return nil, errors.New("GoalAny needs at least one point")
+	}
+	return g, nil
+}
+
+type goalSurface struct{ x, z int }
+
+// GoalSurface is reached at the walkable surface of column x, z.
+func GoalSurface(x, z int) Goal {
+	return goalSurface{x, z}
+}
+
+func (g goalSurface) candidates(w *World) ([]Point, error) {
+	p, ok := w.SurfaceAt(g.x, g.z)
+	if !ok {
+		return nil, fmt.Errorf("%w: no surface at x=%d z=%d", ErrGoalBlocked, g.x, g.z)
+	}
+	return []Point{p}, nil
+}
+
+// Options controls a search. The zero value runs A* without breaking or
+// placing blocks and without node or frontier limits.
+type Options struct {
+	Algorithm      string
+	AllowBreaking  bool
+	AllowPlacing   bool
+	AvoidWater     bool
+	MinimiseHeight bool
+
+	// MaxIterations bounds IDA* deepening rounds; zero allows 1000. Other
+	// algorithms ignore it. Bellman-Ford considers at most 5000 positions
+	// and is otherwise bounded by MaxNodes.
+	MaxIterations int
+	MaxNodes      int
+	MaxFrontier   int
+
+	// Progress, when set, is called from the searching goroutine every
+	// ProgressInterval expanded nodes.
+	Progress         func(Progress)
+	ProgressInterval int
+	Logger           *slog.Logger
+}
+
+type Progress struct {
+	NodesExplored int
+	FrontierSize  int
+	Current       Point
+	Elapsed       time.Duration
+}
+
+type Result struct {
+	Algorithm      string
+	Goal           Point
+	Path           []Point
+	TotalCost      float64
+	NodesExplored  int
+	Duration       time.Duration
+	BlocksBroken   []Point
+	BlocksPlaced   []Point
+	WaterCrossed   int
+	VerticalChange int
+	MaxMemoryUsed  int
+	Iterations     int
+}
+
+// FindPath searches w from start to goal. Failures wrap one of the Err
+// values of this package; cancelling ctx stops the search with ErrCancelled.
+func FindPath(ctx context.Context, w *World, start Point, goal Goal, options Options) (*Result, error) {
+	name := options.Algorithm
+	if name == "" {
+		name = AStar
+	}
+	algorithm, ok := pathfinding.LookupAlgorithm(name)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownAlgorithm, name)
+	}
+	if name == JPS && (options.AllowBreaking || options.AllowPlacing) {
+		return nil, errors.New("jump point search does not support breaking or placing blocks")
+	}
+
+	targets, err := goal.candidates(w)
+	if err != nil {
+		return nil, err
+	}
+	if !w.InBounds(start) {
+		return nil, fmt.Errorf("%w: start %v", ErrOutOfBounds, start)
+	}
+
+	targets = append([]Point(nil), targets...)
+	sort.SliceStable(targets, func(i, j int) bool {
+		return pathfinding.ManhattanDistance(start, targets[i]) < pathfinding.ManhattanDistance(start, targets[j])
+	})
+
+	search := pathfinding.PathfindingOptions{
+		AllowBreaking:    options.AllowBreaking,
+		AllowPlacing:     options.AllowPlacing,
+		AvoidWater:       options.AvoidWater,
+		MinimiseHeight:   options.MinimiseHeight,
+		MaxIterations:    options.MaxIterations,
+		MaxNodes:         options.MaxNodes,
+		MaxFrontier:      options.MaxFrontier,
+		Context:          ctx,
+		ProgressInterval: options.ProgressInterval,
+		Logger:           options.Logger,
+	}
+	if options.Progress != nil {
+		search.Progress = func(p pathfinding.SearchProgress) {
+			options.Progress(Progress{NodesExplored: p.NodesExplored, FrontierSize: p.FrontierSize, Current: p.Current, Elapsed: p.Elapsed})
+		}
+	}
+
+	var best *Result
+	var firstErr error
+	var nodes int
+	var elapsed time.Duration
+	for _, target := range targets {
+		if !w.InBounds(target) {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%w: goal %v", ErrOutOfBounds, target)
+			}
+			continue
+		}
+
+		found := algorithm.Search(start, target, w.w, search)
+		nodes += found.NodesExplored
+		elapsed += found.ComputationTime
+
+		if found.Err != nil {
+			if errors.Is(found.Err, ErrCancelled) {
+				return nil, found.Err
+			}
+			if firstErr == nil {
+				firstErr = found.Err
+			}
+			continue
+		}
+
+		if best == nil || found.TotalCost < best.TotalCost {
+			best = &Result{
+				Algorithm:      algorithm.Name,
+				Goal:           target,
+				Path:           found.Path,
+				TotalCost:      found.TotalCost,
+				NodesExplored:  found.NodesExplored,
+				Duration:       found.ComputationTime,
+				BlocksBroken:   found.BlocksBroken,
+				BlocksPlaced:   found.BlocksPlaced,
+				WaterCrossed:   found.WaterCrossed,
+				VerticalChange: found.VerticalChange,
+				MaxMemoryUsed:  found.MaxMemoryUsed,
+				Iterations:     found.Iterations,
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, firstErr
+	}
+	best.NodesExplored, best.Duration = nodes, elapsed
+	return best, nil
+}
@@ -0,0 +1,167 @@
+package paritone
+
+import (
+	"fmt"
+
+	"github.com/WillKirkmanM/paritone/internal/scenarios"
+	"github.com/WillKirkmanM/paritone/internal/world"
+)
+
+// Block is a block definition. Cells with Walkable blocks can be occupied,
+// Breakable blocks can be mined when breaking is allowed, and MoveCost
+// scales the cost of stepping into the cell.
+type Block struct {
+	Type      string
+	Walkable  bool
+	Breakable bool
+	MoveCost  float64
+}
+
+type BlockData = world.BlockData
+
+type Region = world.Region
+
+func BlockTypes() []string {
+	return world.BlockTypes()
+}
+
+func LookupBlock(blockType string) (Block, bool) {
+	b, ok := world.NewBlock(blockType)
+	if !ok {
+		return Block{}, false
+	}
+	return fromInternal(b), true
+}
+
+func fromInternal(b world.Block) Block {
+	return Block{Type: b.Type, Walkable: b.Walkable, Breakable: b.Breakable, MoveCost: b.MoveCost}
+}
+
+func (b Block) internal() world.Block {
+	return world.Block{Type: b.Type, Walkable: b.Walkable, Breakable: b.Breakable, MoveCost: b.MoveCost}
+}
+
+// World is a sparse voxel world. Cells that were never set are solid and
+// unbreakable. A World may be searched from several goroutines at once but
+// must not be modified while a search is running.
+type World struct {
+	w *world.World
+}
+
+func NewWorld() *World {
+	return &World{w: world.NewWorld()}
+}
+
+// NewBoxWorld returns a world filled with air between min and max inclusive,
+// then applies regions followed by single blocks.
+func NewBoxWorld(min, max Point, regions []Region, blocks []BlockData) (*World, error) {
+	w, err := world.FromBlocks(min, max, regions, blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &World{w: w}, nil
+}
+
+type GeneratorOptions = world.GeneratorOptions
+
+func DefaultGeneratorOptions(seed int64) GeneratorOptions {
+	return world.DefaultGeneratorOptions(seed)
+}
+
+// GenerateWorld builds procedural terrain. The same options always produce
+// the same world.
+func GenerateWorld(options GeneratorOptions) (*World, error) {
+	if options.SizeX <= 0 || options.SizeZ <= 0 || options.Height <= 0 {
+		return nil, fmt.Errorf("generator size %dx%dx%d must be positive", options.SizeX, options.Height, options.SizeZ)
+	}
+	return &World{w: world.Generate(options)}, nil
+}
+
+// Scenario is one of the built-in test worlds with its suggested endpoints.
+type Scenario struct {
+	ID          string
+	Name        string
+	Description string
+	Start       Point
+	Goal        Point
+}
+
+func Scenarios() []Scenario {
+	all := scenarios.All()
+	list := make([]Scenario, len(all))
+	for i, s := range all {
+		list[i] = Scenario{ID: s.ID, Name: s.Name, Description: s.Description, Start: s.Start, Goal: s.Goal}
+	}
+	return list
+}
+
+// ScenarioWorld builds a fresh copy of the scenario's world.
+func ScenarioWorld(id string) (*World, Scenario, error) {
+	s, ok := scenarios.Get(id)
+	if !ok {
+		return nil, Scenario{}, fmt.Errorf("unknown scenario %q", id)
+	}
+	return &World{w: s.World()}, Scenario{ID: s.ID, Name: s.Name, Description: s.Description, Start: s.Start, Goal: s.Goal}, nil
+}
+
+func (w *World) SetBlock(p Point, block Block) {
+	w.w.SetBlock(p, block.internal())
+}
+
+// SetBlockType sets p to one of the predefined BlockTypes.
+func (w *World) SetBlockType(p Point, blockType string) error {
+	b, ok := world.NewBlock(blockType)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownBlock, blockType)
+	}
+	w.w.SetBlock(p, b)
+	return nil
+}
+
+// Apply sets regions and then single blocks, all of which must lie inside
+// the current bounds. Nothing is changed if any of them is invalid.
+func (w *World) Apply(regions []Region, blocks []BlockData) error {
+	return w.w.Apply(regions, blocks)
+}
+
+func (w *World) Block(p Point) (Block, bool) {
+	b, ok := w.w.GetBlock(p)
+	if !ok {
+		return Block{}, false
+	}
+	return fromInternal(b), true
+}
+
+func (w *World) Bounds() (min, max Point) {
+	return w.w.Bounds()
+}
+
+func (w *World) InBounds(p Point) bool {
+	return w.w.InBounds(p)
+}
+
+func (w *World) IsWalkable(p Point) bool {
+	return w.w.IsWalkable(p)
+}
+
+// Len returns the number of cells that have been set, including air.
+func (w *World) Len() int {
+	return len(w.w.Blocks)
+}
+
+// Blocks lists every non-air cell, ordered by Y, then X, then Z.
+func (w *World) Blocks() []BlockData {
+	return w.w.BlockList()
+}
+
+// SurfaceAt returns the highest walkable cell standing on a solid block in
+// the given column.
+func (w *World) SurfaceAt(x, z int) (Point, bool) {
+	return w.w.SurfaceAt(x, z)
+}
+
+// SpawnPoints picks standable points near two opposite corners, as the
+// server does for generated worlds.
+func (w *World) SpawnPoints() (start, goal Point, ok bool) {
+	return world.SpawnPoints(w.w)
+}